@@ -0,0 +1,148 @@
+package ilp
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// defaultLB and defaultUB are the bounds assumed for a variable whose lb/ub
+// slice is nil or too short, matching the usual MILP convention of
+// non-negative, otherwise-unrestricted variables.
+var (
+	defaultLB = 0.0
+	defaultUB = math.Inf(1)
+)
+
+// boundAt returns bounds[i], or the default if bounds is nil/short.
+func boundAt(bounds []float64, i int, def float64) float64 {
+	if i >= len(bounds) {
+		return def
+	}
+	return bounds[i]
+}
+
+// Convert folds lb/ub into extra rows of G/h so the problem can be handed
+// to lp.Simplex, which only understands standard form (A x = b, x >= 0)
+// and has no notion of a per-variable bound of its own.
+//
+// A finite upper bound becomes an extra "x_i <= u" row, same as before.
+// A negative (or -Inf) lower bound can't be represented as a row at all:
+// lp.Simplex's x >= 0 is implicit and un-overridable, so a row like
+// "-x_i <= -l" for l < 0 is automatically satisfied by that implicit bound
+// and enforces nothing - it used to be emitted anyway, silently clamping
+// every such variable back to [0, ub] regardless of what l said. The
+// correct fix is the same variable substitution x_i = x'_i + l (x'_i >= 0)
+// that heuristics/pump.go's buildStandardForm already uses for its
+// L1-projection LP. Convert performs that substitution for every variable
+// whose lower bound is negative and returns the per-variable shift: the
+// caller must apply the same shift to any other system sharing these
+// variables (e.g. A's right-hand side, via ShiftRHS) and undo it on the
+// solved x (x_i = x'_i + shift[i]). ok is false if any variable's lower
+// bound is -Inf, which has no finite constant to shift by and isn't
+// representable this way.
+func Convert(n int, G *mat.Dense, h, lb, ub []float64) (g *mat.Dense, hh, shift []float64, ok bool) {
+	shift = make([]float64, n)
+	for i := 0; i < n; i++ {
+		l := boundAt(lb, i, defaultLB)
+		if math.IsInf(l, -1) {
+			return nil, nil, nil, false
+		}
+		if l < 0 {
+			shift[i] = l
+		}
+	}
+
+	g, hh = G, ShiftRHS(G, h, shift)
+	for i := 0; i < n; i++ {
+		if u := boundAt(ub, i, defaultUB); !math.IsInf(u, 1) {
+			row := make([]float64, n)
+			row[i] = 1
+			g, hh = appendRow(g, hh, row, u-shift[i])
+		}
+		if l := boundAt(lb, i, defaultLB); l > 0 {
+			row := make([]float64, n)
+			row[i] = -1
+			g, hh = appendRow(g, hh, row, -l)
+		}
+	}
+	return g, hh, shift, true
+}
+
+// ShiftRHS adjusts rhs for the variable substitution x = x' + shift (see
+// Convert): rhs[i] - dot(m.Row(i), shift). Exported so a caller folding
+// Convert's shift into an equality system A x = b that Convert never sees
+// can apply the same substitution to b; m may be nil (an empty system),
+// in which case rhs is returned unchanged.
+func ShiftRHS(m *mat.Dense, rhs, shift []float64) []float64 {
+	if m == nil {
+		return append([]float64{}, rhs...)
+	}
+	rows, cols := m.Dims()
+	out := make([]float64, rows)
+	for r := 0; r < rows; r++ {
+		var dot float64
+		for c := 0; c < cols; c++ {
+			dot += m.At(r, c) * shift[c]
+		}
+		out[r] = boundAt(rhs, r, 0) - dot
+	}
+	return out
+}
+
+// boundRowPosition returns the row index Convert assigns the bound row for
+// variable varIdx's upper (wantUB) or lower bound, given the node's current
+// lb/ub and baseRows (the row count of G/h before any bound row is folded
+// in, i.e. len(h) for the structural-and-cut rows Convert is handed).
+// Convert emits bound rows in a fixed order - increasing variable index,
+// upper bound before lower - so a variable's row sits at baseRows plus the
+// number of earlier (index, kind) pairs that are currently finite. Warm
+// -starting (see warmstart.go's ExtendBasisForBound) needs this to know
+// where a newly-finite bound's row lands without re-running Convert. A
+// negative lower bound never gets a row of its own (Convert represents it
+// with a variable shift instead, see Convert's doc comment), so only a
+// strictly positive lb counts as "finite" here, matching Convert exactly.
+func boundRowPosition(varIdx int, wantUB bool, baseRows, n int, lb, ub []float64) int {
+	pos := baseRows
+	for i := 0; i <= varIdx && i < n; i++ {
+		if u := boundAt(ub, i, defaultUB); !math.IsInf(u, 1) {
+			if i == varIdx && wantUB {
+				return pos
+			}
+			pos++
+		}
+		if l := boundAt(lb, i, defaultLB); l > 0 {
+			if i == varIdx && !wantUB {
+				return pos
+			}
+			pos++
+		}
+	}
+	return pos
+}
+
+// tightenUB returns a copy of sp branched on x[varIdx] <= floor(value): the
+// usual "round down" integer branch, applied as a bound tightening instead
+// of an appended bnbConstraints row so the constraint matrix doesn't grow
+// with the depth of the tree.
+func (sp subProblem) tightenUB(varIdx int, value float64) subProblem {
+	child := sp
+	child.ub = append([]float64{}, sp.ub...)
+	for len(child.ub) <= varIdx {
+		child.ub = append(child.ub, defaultUB)
+	}
+	child.ub[varIdx] = math.Floor(value)
+	return child
+}
+
+// tightenLB returns a copy of sp branched on x[varIdx] >= ceil(value): the
+// "round up" counterpart of tightenUB.
+func (sp subProblem) tightenLB(varIdx int, value float64) subProblem {
+	child := sp
+	child.lb = append([]float64{}, sp.lb...)
+	for len(child.lb) <= varIdx {
+		child.lb = append(child.lb, defaultLB)
+	}
+	child.lb[varIdx] = math.Ceil(value)
+	return child
+}
@@ -0,0 +1,107 @@
+package ilp
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+func TestBoundRowPosition_StableForLowerIndexInsertion(t *testing.T) {
+	baseRows, n := 2, 5
+
+	// Parent has already finite bounds on ub[1] and ub[4].
+	parentUB := []float64{math.Inf(1), 3, math.Inf(1), math.Inf(1), 7}
+	parentLB := []float64{0, 0, 0, 0, 0}
+	pos1Parent := boundRowPosition(1, true, baseRows, n, parentLB, parentUB)
+	pos4Parent := boundRowPosition(4, true, baseRows, n, parentLB, parentUB)
+	if pos1Parent != baseRows {
+		t.Fatalf("ub[1] should be the first bound row (pos %d), got %d", baseRows, pos1Parent)
+	}
+	if pos4Parent != baseRows+1 {
+		t.Fatalf("ub[4] should be the second bound row (pos %d), got %d", baseRows+1, pos4Parent)
+	}
+
+	// Child additionally tightens ub[0], a lower index than both existing
+	// bounds, so it must be inserted *before* them.
+	childUB := append([]float64{}, parentUB...)
+	childUB[0] = 2
+	pos0Child := boundRowPosition(0, true, baseRows, n, parentLB, childUB)
+	pos1Child := boundRowPosition(1, true, baseRows, n, parentLB, childUB)
+	pos4Child := boundRowPosition(4, true, baseRows, n, parentLB, childUB)
+
+	if pos0Child != baseRows {
+		t.Fatalf("newly tightened ub[0] should land at the front (pos %d), got %d", baseRows, pos0Child)
+	}
+	if pos1Child != pos1Parent+1 {
+		t.Fatalf("ub[1]'s row should shift down by one once ub[0] is inserted before it, got %d want %d", pos1Child, pos1Parent+1)
+	}
+	if pos4Child != pos4Parent+1 {
+		t.Fatalf("ub[4]'s row should shift down by one once ub[0] is inserted before it, got %d want %d", pos4Child, pos4Parent+1)
+	}
+}
+
+func TestBoundRowPosition_UBBeforeLBSameIndex(t *testing.T) {
+	// A negative lb gets no row of its own (see Convert), so only a
+	// strictly positive lb exercises the "lb row" branch here.
+	lb := []float64{2}
+	ub := []float64{5}
+	ubPos := boundRowPosition(0, true, 0, 1, lb, ub)
+	lbPos := boundRowPosition(0, false, 0, 1, lb, ub)
+	if ubPos != 0 || lbPos != 1 {
+		t.Fatalf("expected ub row before lb row for the same variable, got ub=%d lb=%d", ubPos, lbPos)
+	}
+}
+
+func TestConvert_RejectsFreeVariable(t *testing.T) {
+	if _, _, _, ok := Convert(1, nil, nil, []float64{math.Inf(-1)}, nil); ok {
+		t.Fatalf("expected Convert to reject a variable with lb=-Inf")
+	}
+}
+
+// TestConvert_ShiftsNegativeLowerBound covers the bug a row-only encoding
+// of lb<0 can't fix: a "-x <= -l" row for l<0 is automatically satisfied
+// by lp.Simplex's own implicit x>=0 and enforces nothing, silently
+// clamping the variable back to [0, ub]. Convert must instead shift it.
+func TestConvert_ShiftsNegativeLowerBound(t *testing.T) {
+	n := 1
+	G := mat.NewDense(1, 1, []float64{1}) // x <= 10
+	h := []float64{10}
+	lb := []float64{-5}
+	ub := []float64{math.Inf(1)}
+
+	g, hh, shift, ok := Convert(n, G, h, lb, ub)
+	if !ok {
+		t.Fatalf("expected Convert to succeed for a finite negative lb")
+	}
+	if shift[0] != -5 {
+		t.Fatalf("shift = %v, want [-5]", shift)
+	}
+	if hh[0] != 15 {
+		t.Fatalf("shifted h = %v, want [15] (10 - 1*(-5))", hh)
+	}
+
+	// Build the standard form lp.Simplex needs (one slack column per G
+	// row, the same convention warmstart_test.go and heuristics/pump.go
+	// use) and minimize x, which should bottom out at the shifted lb.
+	rows, cols := g.Dims()
+	A := mat.NewDense(rows, cols+rows, nil)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			A.Set(r, c, g.At(r, c))
+		}
+		A.Set(r, cols+r, 1)
+	}
+	c := append([]float64{1}, make([]float64, rows)...)
+
+	_, xStd, err := lp.Simplex(c, A, hh, 0, nil)
+	if err != nil {
+		t.Fatalf("lp.Simplex: %v", err)
+	}
+
+	x := xStd[0] + shift[0]
+	if math.Abs(x-(-5)) > 1e-6 {
+		t.Fatalf("x = %v, want -5 (the shifted lower bound)", x)
+	}
+}
@@ -0,0 +1,35 @@
+package ilp
+
+import "github.com/rlacjfjin/GoMILP/cuts"
+
+// BranchAndCutOptions configures which cutting-plane generators run during
+// the search, and how aggressively their output is kept around.
+type BranchAndCutOptions struct {
+	// Generators are run, in the given order, at every eligible node.
+	Generators []cuts.Generator
+
+	// MaxDepth limits cut generation to nodes at depth <= MaxDepth from the
+	// root. A negative value means unlimited depth; the zero value (the
+	// default for an unset BranchAndCutOptions) restricts generation to the
+	// root node only.
+	MaxDepth int
+
+	// PurgeAfter is forwarded to cuts.NewPool: pooled cuts that go more than
+	// this many nodes without being binding are dropped. Zero disables
+	// purging.
+	PurgeAfter int
+}
+
+// enabled reports whether any cut generation should happen at all, so the
+// common case (no branch-and-cut configured) can skip tableau
+// reconstruction entirely.
+func (o BranchAndCutOptions) enabled() bool {
+	return len(o.Generators) > 0
+}
+
+// eligible reports whether a node at depth should run cut generation,
+// per MaxDepth's depth<=MaxDepth rule (a negative MaxDepth means
+// unlimited).
+func (o BranchAndCutOptions) eligible(depth int) bool {
+	return o.MaxDepth < 0 || depth <= o.MaxDepth
+}
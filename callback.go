@@ -0,0 +1,167 @@
+package ilp
+
+import "gonum.org/v1/gonum/mat"
+
+// Event identifies a point in the branch-and-cut search at which a
+// NodeCallback is invoked.
+type Event int
+
+const (
+	// EventRelaxationSolved fires right after a node's LP relaxation has
+	// been solved, before any integer-feasibility or bounding checks.
+	EventRelaxationSolved Event = iota
+
+	// EventBeforeBranch fires once a node has been chosen for branching,
+	// before the branching variable is selected and children are created.
+	EventBeforeBranch
+
+	// EventIntegerFeasible fires when a node's relaxation solution already
+	// satisfies every integrality constraint.
+	EventIntegerFeasible
+
+	// EventNodeSelected fires when a node is popped off the search queue,
+	// before its relaxation is solved.
+	EventNodeSelected
+
+	// EventCutRound fires after cut generators have run for a node (see
+	// BranchAndCutOptions), once for the whole round rather than per cut.
+	EventCutRound
+)
+
+// ConstraintSense is the relational operator of a constraint added through
+// TreeContext.
+type ConstraintSense int
+
+const (
+	LessOrEqual ConstraintSense = iota
+	GreaterOrEqual
+	Equal
+)
+
+// NodeCallback is invoked at well-defined events during the search. It is
+// modelled on GLPK's branch-and-cut callback: users can inject lazy
+// constraints for problems like TSP subtour elimination, seed the incumbent
+// from a heuristic, or override the search without forking the solver.
+type NodeCallback func(event Event, tree TreeContext)
+
+// TreeContext exposes the state of the search at the point a NodeCallback
+// fires, along with methods that mutate the search from inside the
+// callback. Mutations are applied by the enumeration tree once the callback
+// returns; they do not take effect immediately.
+type TreeContext interface {
+	// Subproblem returns the node the callback fired for.
+	Subproblem() subProblem
+
+	// RelaxationValue and RelaxationSolution describe the node's last
+	// solved LP relaxation. They are zero-valued before EventNodeSelected's
+	// relaxation has been solved.
+	RelaxationValue() float64
+	RelaxationSolution() []float64
+
+	// Incumbent returns the best integer-feasible solution found so far,
+	// or nil if none has been found yet.
+	Incumbent() *milpSolution
+
+	// QueueLen returns the number of nodes still waiting to be explored.
+	QueueLen() int
+
+	// AddLazyConstraint adds a·x {sense} rhs to every subsequent subproblem
+	// in the tree, including the current node's children.
+	AddLazyConstraint(a []float64, sense ConstraintSense, rhs float64)
+
+	// AddCut adds a·x {sense} rhs to the current node and to the children
+	// later branched from it, but not to any other node in the tree.
+	AddCut(a []float64, sense ConstraintSense, rhs float64)
+
+	// SetHeuristicSolution offers x as a candidate incumbent. It is
+	// adopted only if it is integer-feasible and improves on the current
+	// incumbent.
+	SetHeuristicSolution(x []float64)
+
+	// PruneNode discards the current node without exploring its children.
+	PruneNode()
+}
+
+// treeContext is the concrete TreeContext handed to NodeCallback. The
+// enumeration tree constructs one per event, invokes the callback, then
+// drains lazy/cut/heuristic/pruned to act on whatever the callback set.
+type treeContext struct {
+	node        *subProblem
+	relaxationZ float64
+	relaxationX []float64
+	incumbent   *milpSolution
+	queueLen    int
+	lazy        []pendingConstraint
+	cuts        []pendingConstraint
+	heuristic   []float64
+	pruned      bool
+}
+
+// pendingConstraint is a constraint queued by a callback before it has been
+// normalized into a G/h (or A/b, for Equal) row.
+type pendingConstraint struct {
+	a     []float64
+	sense ConstraintSense
+	rhs   float64
+}
+
+func (c *treeContext) Subproblem() subProblem        { return *c.node }
+func (c *treeContext) RelaxationValue() float64      { return c.relaxationZ }
+func (c *treeContext) RelaxationSolution() []float64 { return c.relaxationX }
+func (c *treeContext) Incumbent() *milpSolution      { return c.incumbent }
+func (c *treeContext) QueueLen() int                 { return c.queueLen }
+func (c *treeContext) PruneNode()                    { c.pruned = true }
+
+func (c *treeContext) AddLazyConstraint(a []float64, sense ConstraintSense, rhs float64) {
+	c.lazy = append(c.lazy, pendingConstraint{a: a, sense: sense, rhs: rhs})
+}
+
+func (c *treeContext) AddCut(a []float64, sense ConstraintSense, rhs float64) {
+	c.cuts = append(c.cuts, pendingConstraint{a: a, sense: sense, rhs: rhs})
+}
+
+func (c *treeContext) SetHeuristicSolution(x []float64) {
+	c.heuristic = x
+}
+
+// applyToInequalities normalizes p into one or two `<=` rows and appends
+// them to (g, h), returning the grown matrix and rhs vector. Equal is
+// implemented as the usual pair of opposing `<=` rows since subProblem has
+// no notion of adding to A/b past construction time.
+func (p pendingConstraint) applyToInequalities(g *mat.Dense, h []float64) (*mat.Dense, []float64) {
+	switch p.sense {
+	case LessOrEqual:
+		return appendRow(g, h, p.a, p.rhs)
+	case GreaterOrEqual:
+		return appendRow(g, h, negate(p.a), -p.rhs)
+	default: // Equal
+		g, h = appendRow(g, h, p.a, p.rhs)
+		return appendRow(g, h, negate(p.a), -p.rhs)
+	}
+}
+
+func negate(a []float64) []float64 {
+	out := make([]float64, len(a))
+	for i, v := range a {
+		out[i] = -v
+	}
+	return out
+}
+
+// appendRow grows g by one row [a] and h by one entry [b]. mat.Dense has no
+// in-place row-append, so this allocates a new backing matrix sized for the
+// existing rows plus one.
+func appendRow(g *mat.Dense, h []float64, a []float64, b float64) (*mat.Dense, []float64) {
+	rows, cols := 0, len(a)
+	if g != nil {
+		rows, cols = g.Dims()
+	}
+
+	grown := mat.NewDense(rows+1, cols, nil)
+	if g != nil {
+		grown.Copy(g)
+	}
+	grown.SetRow(rows, a)
+
+	return grown, append(append([]float64{}, h...), b)
+}
@@ -0,0 +1,137 @@
+package cuts
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// coverTol is the slack tolerated when checking whether a knapsack row is
+// violated by the fractional relaxation.
+const coverTol = 1e-9
+
+// CoverGenerator derives knapsack cover cuts from the problem's
+// ≤-rows whose nonzero variables are all binary and all have a positive
+// coefficient — i.e. rows that are genuinely a 0/1 knapsack constraint
+// sum a_j x_j <= b, a_j > 0. Given such a row, it greedily grows a cover C
+// (sum_{j in C} a_j > b) from the variables with the largest relaxation
+// values first, then emits the standard cover inequality
+// sum_{j in C} x_j <= |C| - 1. The greedy cover is not reduced to a
+// minimal one.
+//
+// A mixed-sign row isn't a knapsack at all (e.g. a precedence constraint
+// x - y <= 0), and the cover derivation is unsound for it: flipping the
+// sign of a variable's bound on one side of the inequality invalidates the
+// "weight" accumulation the greedy cover search relies on, and can produce
+// a cut that excludes feasible integer points. binaryKnapsackVars rejects
+// any such row outright.
+type CoverGenerator struct {
+	// Rows restricts cover search to these indices into G/h. Nil means
+	// every row is a candidate.
+	Rows []int
+}
+
+func (CoverGenerator) Name() Source { return SourceCover }
+
+func (g CoverGenerator) Generate(ctx Context) []Row {
+	if ctx.G == nil {
+		return nil
+	}
+
+	nRows, nCols := ctx.G.Dims()
+	candidates := g.Rows
+	if candidates == nil {
+		candidates = make([]int, nRows)
+		for i := range candidates {
+			candidates[i] = i
+		}
+	}
+
+	var out []Row
+	for _, r := range candidates {
+		if r < 0 || r >= nRows {
+			continue
+		}
+		vars := binaryKnapsackVars(ctx.G, r, nCols, ctx.IntegralityConstraints, ctx.LB, ctx.UB)
+		if vars == nil {
+			continue
+		}
+		cover := findCover(ctx.G, r, ctx.H[r], ctx.X, vars)
+		if cover == nil {
+			continue
+		}
+		coeffs := make([]float64, nCols)
+		for _, j := range cover {
+			coeffs[j] = 1
+		}
+		out = append(out, Row{Coeffs: coeffs, RHS: float64(len(cover) - 1)})
+	}
+	return out
+}
+
+// binaryKnapsackVars returns the column indices with a nonzero coefficient
+// in row r, provided the row is actually a 0/1 knapsack: every such column
+// must carry a strictly positive coefficient, be integrality-constrained,
+// and be bounded to exactly [0, 1]. It returns nil if any of that fails to
+// hold for any participating column, since cover cuts don't apply to rows
+// that aren't 0/1 knapsacks (a negative coefficient means the row is more
+// likely a precedence-style constraint, which the cover derivation isn't
+// valid for).
+func binaryKnapsackVars(g *mat.Dense, r, nCols int, integrality []bool, lb, ub []float64) []int {
+	var vars []int
+	for j := 0; j < nCols; j++ {
+		a := g.At(r, j)
+		if a == 0 {
+			continue
+		}
+		if a < 0 {
+			return nil
+		}
+		if j >= len(integrality) || !integrality[j] {
+			return nil
+		}
+		if boundAt(lb, j, 0) != 0 || boundAt(ub, j, math.Inf(1)) != 1 {
+			return nil
+		}
+		vars = append(vars, j)
+	}
+	return vars
+}
+
+// boundAt returns bounds[i], or def if bounds is nil/too short.
+func boundAt(bounds []float64, i int, def float64) float64 {
+	if i >= len(bounds) {
+		return def
+	}
+	return bounds[i]
+}
+
+// findCover greedily grows a cover for row r: it walks the row's
+// binary variables in decreasing order of relaxation value (the ones
+// closest to being "in" the knapsack) and keeps adding weight until the
+// accumulated weight exceeds b, which is exactly the condition for the
+// resulting set to be a valid cover.
+func findCover(g *mat.Dense, r int, b float64, x []float64, vars []int) []int {
+	sort.Slice(vars, func(a, c int) bool { return x[vars[a]] > x[vars[c]] })
+
+	var cover []int
+	var weight float64
+	for _, j := range vars {
+		weight += g.At(r, j)
+		cover = append(cover, j)
+		if weight > b+coverTol {
+			// only useful if the cover is actually violated by x, i.e. all
+			// of its members currently sit near 1.
+			var sum float64
+			for _, k := range cover {
+				sum += x[k]
+			}
+			if sum > float64(len(cover))-1+coverTol {
+				return cover
+			}
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package cuts
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// TestCoverGenerator_RejectsMixedSignRow covers the precedence-constraint
+// counterexample: -x0 + x1 + x2 <= 1, all three binary, relaxation
+// x0=0, x1=0.9, x2=0.9. The naive (coefficient-sign-blind) generator would
+// emit x1+x2<=1, which excludes the feasible integer point (1,1,1). A
+// correct generator must refuse to treat this row as a knapsack at all.
+func TestCoverGenerator_RejectsMixedSignRow(t *testing.T) {
+	g := mat.NewDense(1, 3, []float64{-1, 1, 1})
+	ctx := Context{
+		G:                      g,
+		H:                      []float64{1},
+		X:                      []float64{0, 0.9, 0.9},
+		IntegralityConstraints: []bool{true, true, true},
+		LB:                     []float64{0, 0, 0},
+		UB:                     []float64{1, 1, 1},
+	}
+
+	rows := CoverGenerator{}.Generate(ctx)
+	for _, r := range rows {
+		x := []float64{1, 1, 1}
+		var sum float64
+		for j, c := range r.Coeffs {
+			sum += c * x[j]
+		}
+		if sum > r.RHS {
+			t.Fatalf("generated cut %+v excludes feasible integer point (1,1,1)", r)
+		}
+	}
+}
+
+// TestCoverGenerator_RejectsNonBinaryBounds ensures a variable flagged
+// integer but bounded wider than [0,1] (e.g. [0,5]) isn't treated as part
+// of a 0/1 knapsack.
+func TestCoverGenerator_RejectsNonBinaryBounds(t *testing.T) {
+	g := mat.NewDense(1, 2, []float64{1, 1})
+	ctx := Context{
+		G:                      g,
+		H:                      []float64{1},
+		X:                      []float64{0.9, 0.9},
+		IntegralityConstraints: []bool{true, true},
+		LB:                     []float64{0, 0},
+		UB:                     []float64{5, 1},
+	}
+
+	if rows := (CoverGenerator{}).Generate(ctx); len(rows) != 0 {
+		t.Fatalf("expected no cover cut with a non-binary bound in the row, got %+v", rows)
+	}
+}
+
+// TestCoverGenerator_ValidCover exercises the intended case: a genuine 0/1
+// knapsack row where the cover cut correctly excludes the fractional point
+// but not the all-one-but-one integer points.
+func TestCoverGenerator_ValidCover(t *testing.T) {
+	g := mat.NewDense(1, 3, []float64{1, 1, 1})
+	ctx := Context{
+		G:                      g,
+		H:                      []float64{1},
+		X:                      []float64{0.9, 0.9, 0.9},
+		IntegralityConstraints: []bool{true, true, true},
+		LB:                     []float64{0, 0, 0},
+		UB:                     []float64{1, 1, 1},
+	}
+
+	rows := CoverGenerator{}.Generate(ctx)
+	if len(rows) != 1 {
+		t.Fatalf("expected exactly one cover cut, got %d", len(rows))
+	}
+	if rows[0].RHS != 1 {
+		t.Fatalf("expected cut RHS 1 (|C|-1 for the 2-element cover found), got %v", rows[0].RHS)
+	}
+}
@@ -0,0 +1,67 @@
+// Package cuts generates cutting planes from a solved LP relaxation: rows
+// that are valid for every integer-feasible point of the original problem
+// but cut off its current fractional vertex, tightening the relaxation
+// without discarding any integer solution. ilp's branch-and-cut loop calls
+// a configured set of Generators at each eligible node and appends
+// whatever rows they return to that node's G/h before re-solving.
+package cuts
+
+import "gonum.org/v1/gonum/mat"
+
+// Row is a single inequality `Coeffs · x <= RHS`, expressed over the same
+// variables and in the same sense as the problem's G/h rows, so it can be
+// appended directly onto a node's G/h before re-solving.
+type Row struct {
+	Coeffs []float64
+	RHS    float64
+}
+
+// Source identifies which generator produced a Row.
+type Source string
+
+const (
+	SourceGomory Source = "gomory"
+	SourceCover  Source = "cover"
+)
+
+// Tableau is the slice of an optimal simplex basis a Generator needs: which
+// structural/slack columns are basic, the basis-inverse applied to the
+// constraint matrix (so row i is the tableau row for Basis[i]), and the
+// corresponding basic solution values.
+type Tableau struct {
+	Basis []int
+	BinvA *mat.Dense // Binv * A: every column expressed in terms of the current basis
+	BinvB []float64  // Binv * b: the basic solution values, one per row
+}
+
+// Context is everything a Generator needs to look at a solved LP relaxation
+// and propose additional rows.
+type Context struct {
+	Tab                    Tableau
+	IntegralityConstraints []bool
+	X                      []float64 // full relaxation solution, length len(IntegralityConstraints)
+
+	// G and H are the node's current inequality rows (including any prior
+	// cuts and callback-added rows; branching bounds are in LB/UB, not
+	// here), needed by generators that reason about the
+	// original constraint structure rather than just the tableau.
+	G *mat.Dense
+	H []float64
+
+	// LB and UB are the node's current per-variable bounds (see ilp's
+	// Convert), needed by generators that require more than
+	// IntegralityConstraints to reason about a variable's feasible range,
+	// e.g. CoverGenerator confirming a column is actually binary rather
+	// than a general integer. A nil entry (or one shorter than
+	// IntegralityConstraints) means that variable is at the solver's
+	// default bounds of lb=0, ub=+Inf.
+	LB, UB []float64
+}
+
+// Generator produces candidate cutting planes for a solved LP relaxation.
+// Implementations must be side-effect free: Generate may be called once per
+// node per round, and nodes may be explored concurrently.
+type Generator interface {
+	Name() Source
+	Generate(ctx Context) []Row
+}
@@ -0,0 +1,108 @@
+package cuts
+
+import "math"
+
+// gomoryTol is how close to an integer a basic variable's value must be
+// before it is treated as integral rather than a cut candidate.
+const gomoryTol = 1e-7
+
+// GomoryGenerator derives fractional Gomory mixed-integer cuts from the
+// tableau of a solved LP relaxation, one candidate cut per fractional basic
+// integer variable.
+//
+// ctx.Tab.BinvA's columns cover the node's full standard form - structural
+// variables followed by one slack column per G row (the same convention
+// ilp's warm-starting and heuristics/pump.go's buildStandardForm use) - so
+// a nonbasic column past the structural ones is a slack, not a variable of
+// the original problem. A nonbasic slack means its row is currently tight,
+// the common case at an LP vertex; Generate substitutes it back out via
+// ctx.G/ctx.H (slack_j = H[r] - G[r]·x for its row r) so every emitted cut
+// is expressed over the problem's own variables rather than silently
+// dropping the slack's contribution, which would otherwise produce an
+// invalid cut that can exclude feasible integer points.
+type GomoryGenerator struct{}
+
+func (GomoryGenerator) Name() Source { return SourceGomory }
+
+func (GomoryGenerator) Generate(ctx Context) []Row {
+	basic := make(map[int]bool, len(ctx.Tab.Basis))
+	for _, j := range ctx.Tab.Basis {
+		basic[j] = true
+	}
+
+	n := len(ctx.IntegralityConstraints)
+	_, totalCols := ctx.Tab.BinvA.Dims()
+
+	var rows []Row
+	for row, basicVar := range ctx.Tab.Basis {
+		if basicVar >= n || !ctx.IntegralityConstraints[basicVar] {
+			continue
+		}
+
+		xi := ctx.Tab.BinvB[row]
+		f := xi - math.Floor(xi)
+		if f < gomoryTol || f > 1-gomoryTol {
+			continue
+		}
+
+		// accum holds, for each structural variable, the coefficient of
+		// `sum_j psi(a_ij) var_j >= f` once every nonbasic slack has been
+		// substituted back out via ctx.G/ctx.H; fPrime is f adjusted by
+		// the constant term that substitution introduces.
+		accum := make([]float64, n)
+		fPrime := f
+		eliminated := true
+		for j := 0; j < totalCols; j++ {
+			if basic[j] {
+				continue
+			}
+			aij := ctx.Tab.BinvA.At(row, j)
+
+			if j < n {
+				accum[j] += psi(aij, f, ctx.IntegralityConstraints[j])
+				continue
+			}
+
+			r := j - n
+			if ctx.G == nil || r >= len(ctx.H) {
+				eliminated = false
+				break
+			}
+			psiVal := psi(aij, f, false) // slack columns are always continuous
+			for k := 0; k < n; k++ {
+				accum[k] -= psiVal * ctx.G.At(r, k)
+			}
+			fPrime -= psiVal * ctx.H[r]
+		}
+		if !eliminated {
+			continue
+		}
+
+		coeffs := make([]float64, n)
+		for k := range coeffs {
+			coeffs[k] = -accum[k]
+		}
+		// sum_k accum[k] x_k >= fPrime, rewritten into the problem's G*x <= h sense.
+		rows = append(rows, Row{Coeffs: coeffs, RHS: -fPrime})
+	}
+
+	return rows
+}
+
+// psi applies the Gomory mixed-integer rounding function to a single
+// tableau coefficient: continuous non-basics round linearly, integer
+// non-basics round on their fractional part.
+func psi(a, f float64, integer bool) float64 {
+	if !integer {
+		if a >= 0 {
+			return a
+		}
+		return a * f / (f - 1)
+	}
+
+	fa := a - math.Floor(a)
+	if fa <= f {
+		return fa
+	}
+	return f * (1 - fa) / (1 - f)
+}
@@ -0,0 +1,111 @@
+package cuts
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGomoryGenerator_SkipsIntegralAndContinuousRows(t *testing.T) {
+	ctx := Context{
+		Tab: Tableau{
+			Basis: []int{0, 1},
+			BinvA: mat.NewDense(2, 2, []float64{1, 0, 0, 1}),
+			BinvB: []float64{3, 2}, // both already integral
+		},
+		IntegralityConstraints: []bool{true, false},
+	}
+
+	if rows := (GomoryGenerator{}).Generate(ctx); len(rows) != 0 {
+		t.Fatalf("expected no cut for an integral basic solution, got %+v", rows)
+	}
+}
+
+func TestGomoryGenerator_FractionalBasicVariable(t *testing.T) {
+	// One row, basic var 0 fractional at 2.5, non-basic continuous column 1
+	// with coefficient 0.5.
+	ctx := Context{
+		Tab: Tableau{
+			Basis: []int{0},
+			BinvA: mat.NewDense(1, 2, []float64{1, 0.5}),
+			BinvB: []float64{2.5},
+		},
+		IntegralityConstraints: []bool{true, false},
+	}
+
+	rows := (GomoryGenerator{}).Generate(ctx)
+	if len(rows) != 1 {
+		t.Fatalf("expected one cut, got %d", len(rows))
+	}
+	if rows[0].RHS != -0.5 {
+		t.Fatalf("expected RHS -f = -0.5, got %v", rows[0].RHS)
+	}
+	if rows[0].Coeffs[1] != -0.5 {
+		t.Fatalf("expected continuous coeff -psi(0.5, 0.5) = -0.5, got %v", rows[0].Coeffs[1])
+	}
+}
+
+// TestGomoryGenerator_EliminatesNonbasicSlack covers the case a naive
+// structural-only loop drops entirely. One integer structural variable
+// (n=1) with a single G row 2*x0 <= 5: the LP-optimal vertex sits at the
+// tight fractional point x0=2.5 (slack nonbasic at 0). The tableau row for
+// basic x0 is x0 = 2.5 - 0.5*s, i.e. BinvA=[1, 0.5], BinvB=[2.5]. The
+// emitted cut must fold the slack's contribution back through G/H rather
+// than silently omitting it, and must not exclude any integer-feasible
+// point of the real constraint (x0 <= 2, the true integer optimum).
+func TestGomoryGenerator_EliminatesNonbasicSlack(t *testing.T) {
+	ctx := Context{
+		Tab: Tableau{
+			Basis: []int{0}, // x0 basic, slack column 1 nonbasic
+			BinvA: mat.NewDense(1, 2, []float64{1, 0.5}),
+			BinvB: []float64{2.5},
+		},
+		IntegralityConstraints: []bool{true},
+		G:                      mat.NewDense(1, 1, []float64{2}),
+		H:                      []float64{5},
+	}
+
+	rows := (GomoryGenerator{}).Generate(ctx)
+	if len(rows) != 1 {
+		t.Fatalf("expected one cut, got %d: %+v", len(rows), rows)
+	}
+
+	// f=0.5, psi(0.5,0.5,false)=0.5 (slack is continuous, a>=0).
+	// Substituting slack = H[0] - G[0][0]*x0 = 5 - 2*x0 into
+	// `psi*slack >= f` gives 0.5*(5-2*x0) >= 0.5, i.e. -x0 >= 0.5-2.5=-2,
+	// i.e. accum[0]=-1, fPrime=-2. Row: coeffs=-accum=[1], RHS=-fPrime=2.
+	if rows[0].Coeffs[0] != 1 {
+		t.Fatalf("coeffs = %v, want [1]", rows[0].Coeffs)
+	}
+	if rows[0].RHS != 2 {
+		t.Fatalf("RHS = %v, want 2", rows[0].RHS)
+	}
+
+	// The cut (x0 <= 2) must cut off the fractional vertex (2.5) without
+	// excluding the true integer optimum of 2*x0<=5, which is x0=2.
+	if rows[0].Coeffs[0]*2.5 <= rows[0].RHS {
+		t.Fatalf("cut should exclude the fractional vertex x0=2.5")
+	}
+	if rows[0].Coeffs[0]*2 > rows[0].RHS+1e-9 {
+		t.Fatalf("cut excludes the integer-feasible point x0=2: %v*2 > %v", rows[0].Coeffs[0], rows[0].RHS)
+	}
+}
+
+func TestPsi(t *testing.T) {
+	cases := []struct {
+		a, f    float64
+		integer bool
+		want    float64
+	}{
+		{a: 0.5, f: 0.5, integer: false, want: 0.5},
+		{a: -0.5, f: 0.5, integer: false, want: -0.5 * 0.5 / (0.5 - 1)},
+		{a: 0.3, f: 0.5, integer: true, want: 0.3},
+		{a: 0.7, f: 0.5, integer: true, want: 0.5 * (1 - 0.7) / (1 - 0.5)},
+	}
+	for _, c := range cases {
+		if got := psi(c.a, c.f, c.integer); math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("psi(%v, %v, %v) = %v, want %v", c.a, c.f, c.integer, got, c.want)
+		}
+	}
+}
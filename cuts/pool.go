@@ -0,0 +1,100 @@
+package cuts
+
+// Pool tracks cuts generated across the branch-and-cut tree so that
+// descendants of a node can reuse their ancestors' cuts instead of
+// regenerating them, and so cuts that stop being binding can be dropped
+// once they have been idle for a while.
+type Pool struct {
+	rows       []Row
+	sources    []Source
+	lastActive []int // node id at which each row was last binding
+	ids        []int // stable id for each row, parallel to rows
+
+	nextID int
+
+	// purgeAfter is the number of nodes a row may go un-binding before
+	// Purge drops it. Zero disables purging.
+	purgeAfter int
+}
+
+// NewPool returns an empty pool that purges rows idle for more than
+// purgeAfter nodes.
+func NewPool(purgeAfter int) *Pool {
+	return &Pool{purgeAfter: purgeAfter}
+}
+
+// Add inserts a newly generated row into the pool, recording nodeID as the
+// node it was generated (and therefore last known binding) at, and returns
+// a stable id for the row that Touch can use later regardless of how
+// Purge has since compacted the pool's backing slices.
+func (p *Pool) Add(r Row, src Source, nodeID int) int {
+	id := p.nextID
+	p.nextID++
+
+	p.rows = append(p.rows, r)
+	p.sources = append(p.sources, src)
+	p.lastActive = append(p.lastActive, nodeID)
+	p.ids = append(p.ids, id)
+	return id
+}
+
+// Rows returns every row currently in the pool, e.g. to append to a node's
+// G/h before re-solving its relaxation.
+func (p *Pool) Rows() []Row {
+	return p.rows
+}
+
+// Entries returns every pooled row alongside the stable id Add returned
+// for it, in the same order as Rows, so a caller can find the id of a row
+// it only knows positionally (e.g. from matching it against a re-solved
+// relaxation) before calling Touch.
+func (p *Pool) Entries() []PoolEntry {
+	out := make([]PoolEntry, len(p.rows))
+	for i, r := range p.rows {
+		out[i] = PoolEntry{Row: r, ID: p.ids[i]}
+	}
+	return out
+}
+
+// Touch marks the row identified by id - the value Add returned for it -
+// as binding at nodeID, keeping it alive. id is a stable identifier rather
+// than a positional index, so it stays valid even after Purge has
+// compacted the pool in the meantime.
+func (p *Pool) Touch(id, nodeID int) {
+	for i, rowID := range p.ids {
+		if rowID == id {
+			p.lastActive[i] = nodeID
+			return
+		}
+	}
+}
+
+// Purge drops every row that has not been binding within purgeAfter nodes
+// of nodeID. Call once per node, after checking which pool rows were tight
+// in that node's relaxation.
+func (p *Pool) Purge(nodeID int) {
+	if p.purgeAfter <= 0 {
+		return
+	}
+
+	rows := p.rows[:0]
+	sources := p.sources[:0]
+	lastActive := p.lastActive[:0]
+	ids := p.ids[:0]
+	for i, r := range p.rows {
+		if nodeID-p.lastActive[i] > p.purgeAfter {
+			continue
+		}
+		rows = append(rows, r)
+		sources = append(sources, p.sources[i])
+		lastActive = append(lastActive, p.lastActive[i])
+		ids = append(ids, p.ids[i])
+	}
+	p.rows, p.sources, p.lastActive, p.ids = rows, sources, lastActive, ids
+}
+
+// PoolEntry pairs a pooled Row with the stable id Add returned for it.
+type PoolEntry struct {
+	Row
+	ID int
+}
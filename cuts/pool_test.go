@@ -0,0 +1,30 @@
+package cuts
+
+import "testing"
+
+func TestPool_PurgeDropsIdleRows(t *testing.T) {
+	p := NewPool(2)
+	p.Add(Row{Coeffs: []float64{1}, RHS: 1}, SourceGomory, 0)
+	p.Add(Row{Coeffs: []float64{1}, RHS: 2}, SourceCover, 0)
+
+	p.Touch(0, 1) // row 0 stays binding; row 1 goes idle
+
+	p.Purge(3) // row 1 last active at node 0, now 3 nodes stale (> purgeAfter=2)
+
+	rows := p.Rows()
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row to survive purge, got %d: %+v", len(rows), rows)
+	}
+	if rows[0].RHS != 1 {
+		t.Fatalf("expected the touched row (RHS 1) to survive, got %+v", rows[0])
+	}
+}
+
+func TestPool_PurgeDisabledWhenZero(t *testing.T) {
+	p := NewPool(0)
+	p.Add(Row{Coeffs: []float64{1}, RHS: 1}, SourceGomory, 0)
+	p.Purge(1000)
+	if len(p.Rows()) != 1 {
+		t.Fatalf("expected purge to be a no-op when purgeAfter is 0")
+	}
+}
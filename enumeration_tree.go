@@ -0,0 +1,922 @@
+package ilp
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rlacjfjin/GoMILP/cuts"
+	"github.com/rlacjfjin/GoMILP/heuristics"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// feasTol is the tolerance used to decide whether a relaxation value is
+// already integral, or whether one objective value improves on another.
+// Matches heuristics.feasTol (see heuristics/heuristics.go); the two
+// packages don't share a constant since heuristics keeps its own copy
+// unexported.
+const feasTol = 1e-6
+
+// solution is a concrete point the search has found: x paired with its
+// objective value z. err is set instead, with x and z left zero, when the
+// node it came from couldn't be solved at all - currently only produced
+// for the root (see INITIAL_RELAXATION_NOT_FEASIBLE).
+type solution struct {
+	x   []float64
+	z   float64
+	err error
+}
+
+// bnbConstraint is a single inequality a·x <= rhs queued onto a subproblem
+// after it was created, rather than branched into a tightened bound: by a
+// NodeCallback's AddLazyConstraint/AddCut (see callback.go), or by a
+// cuts.Generator. Kept separate from G/h so a node carries its ancestors'
+// extra rows without mutating the problem-level matrices every other node
+// shares.
+type bnbConstraint struct {
+	a   []float64
+	rhs float64
+}
+
+// subProblem is one node of the branch-and-bound tree: the original
+// problem's numerical definition, narrowed by whatever bound tightenings
+// (see bounds.go's tightenUB/tightenLB) and bnbConstraints its ancestors
+// branched on or queued.
+type subProblem struct {
+	id int
+
+	c                      []float64
+	A                      *mat.Dense
+	b                      []float64
+	G                      *mat.Dense
+	h                      []float64
+	integralityConstraints []bool
+
+	// lb, ub are this node's per-variable bounds; see bounds.go's Convert,
+	// tightenUB and tightenLB.
+	lb, ub []float64
+
+	// bnbConstraints are extra <= rows folded into G/h at solve time (see
+	// effectiveGH), queued by a NodeCallback or a cut generator rather than
+	// branched on as a bound.
+	bnbConstraints []bnbConstraint
+
+	// basis is the parent's optimal standard-form basis, used to warm
+	// start this node's relaxation solve (see warmstart.go). Nil for the
+	// root, which has no parent to warm start from.
+	basis basis
+
+	// boundHint lets solveRelaxationWarm extend basis across the one new
+	// Convert bound row branching on this node's own bound introduced,
+	// instead of discarding basis outright for a dimension mismatch (see
+	// warmstart.go's BoundBranchHint). Nil when branching didn't add a new
+	// row - e.g. the bound it tightened was already finite in the parent -
+	// in which case basis already matches this node's row count as-is.
+	boundHint *BoundBranchHint
+
+	depth int
+}
+
+// bnbDecision classifies the outcome the search reached for a subproblem,
+// both for expectedFailures (ilp.go) and for bnbMiddleware instrumentation.
+type bnbDecision int
+
+const (
+	// SUBPROBLEM_IS_DEGENERATE marks a node whose relaxation came back
+	// infeasible (lp.ErrInfeasible): its feasible region is empty, so it -
+	// and everything below it - is pruned without producing a candidate.
+	SUBPROBLEM_IS_DEGENERATE bnbDecision = iota
+
+	// SUBPROBLEM_NOT_FEASIBLE marks a node whose relaxation was singular
+	// (lp.ErrSingular) and so couldn't be solved to a definite vertex;
+	// treated the same as an infeasible node and pruned.
+	SUBPROBLEM_NOT_FEASIBLE
+
+	// SUBPROBLEM_PRUNED_BY_BOUND marks a node whose relaxation objective is
+	// no better than the current incumbent, so branching it further could
+	// never improve on what's already been found.
+	SUBPROBLEM_PRUNED_BY_BOUND
+
+	// SUBPROBLEM_INTEGER_FEASIBLE marks a node whose relaxation already
+	// satisfies every integrality constraint: a candidate incumbent with
+	// nothing left to branch on.
+	SUBPROBLEM_INTEGER_FEASIBLE
+
+	// SUBPROBLEM_BRANCHED marks a node that was split into two children on
+	// a fractional integer variable.
+	SUBPROBLEM_BRANCHED
+
+	// SUBPROBLEM_PRUNED_BY_CALLBACK marks a node a NodeCallback discarded
+	// via TreeContext.PruneNode before the search would otherwise have
+	// branched or adopted it.
+	SUBPROBLEM_PRUNED_BY_CALLBACK
+)
+
+// bnbMiddleware is an internal instrumentation hook invoked once per
+// subproblem as the tree disposes of it (pruned, branched, or resolved to
+// an incumbent), letting tests and callers observe search behavior without
+// threading that bookkeeping through the public NodeCallback API. Nil
+// means no instrumentation.
+type bnbMiddleware func(sp subProblem, decision bnbDecision)
+
+// BranchHeuristic selects which fractional integer variable a node
+// branches on.
+type BranchHeuristic int
+
+const (
+	// MostFractional branches on the integrality-constrained variable
+	// whose relaxation value is farthest from an integer (closest to
+	// x.5) - the "maxFun" heuristic milpProblem.branchingHeuristic
+	// defaults to.
+	MostFractional BranchHeuristic = iota
+
+	// FirstFractional names branching on the lowest-indexed fractional
+	// integer variable. chooseBranchVar does not read the configured
+	// BranchHeuristic: every node branches on the most-fractional variable,
+	// whichever value is set.
+	FirstFractional
+)
+
+// feasibleForIP reports whether every integrality-constrained component of
+// x is already an integer, within feasTol.
+func feasibleForIP(integralityConstraints []bool, x []float64) bool {
+	for i, integer := range integralityConstraints {
+		if integer && math.Abs(x[i]-math.Round(x[i])) > feasTol {
+			return false
+		}
+	}
+	return true
+}
+
+// enumerationTree drives the branch-and-bound search over subProblems
+// descended from an initial LP relaxation, depth-first, pruning a node as
+// soon as its relaxation is no better than the current incumbent.
+type enumerationTree struct {
+	instrumentation bnbMiddleware
+
+	branchAndCut     BranchAndCutOptions
+	nodeCallback     NodeCallback
+	primalHeuristics HeuristicOptions
+	pool             *SolutionPool
+
+	// cutPool holds every row generated by branchAndCut.Generators across
+	// the whole search, shared by every node so a cut generated for one
+	// branch can be reused by another instead of regenerated. Nil when
+	// branchAndCut is disabled.
+	cutPool *cuts.Pool
+
+	root  subProblem
+	queue *nodeQueue
+
+	mu        sync.Mutex
+	nextID    int
+	incumbent *solution
+
+	// lazyMu guards lazyConstraints, the rows a NodeCallback has queued via
+	// TreeContext.AddLazyConstraint. Folded into every subproblem's
+	// effectiveGH from the point they're added onward; a separate mutex
+	// from mu since lazyConstraints is read from effectiveGH on the same
+	// call path as incumbent-guarded methods like bestZ.
+	lazyMu          sync.Mutex
+	lazyConstraints []bnbConstraint
+
+	// heuristicMu guards heuristicCount, which tracks how many
+	// depth-eligible nodes have gone by so primalHeuristics.Frequency can
+	// skip all but every Frequency-th one.
+	heuristicMu    sync.Mutex
+	heuristicCount int
+}
+
+// newEnumerationTree returns a tree ready to search root's descendants.
+// instrumentation, branchAndCut, nodeCallback, primalHeuristics and pool
+// are threaded straight from milpProblem.solve.
+func newEnumerationTree(root subProblem, instrumentation bnbMiddleware, branchAndCut BranchAndCutOptions, nodeCallback NodeCallback, primalHeuristics HeuristicOptions, pool *SolutionPool) *enumerationTree {
+	var cutPool *cuts.Pool
+	if branchAndCut.enabled() {
+		cutPool = cuts.NewPool(branchAndCut.PurgeAfter)
+	}
+
+	return &enumerationTree{
+		instrumentation:  instrumentation,
+		branchAndCut:     branchAndCut,
+		nodeCallback:     nodeCallback,
+		primalHeuristics: primalHeuristics,
+		pool:             pool,
+		cutPool:          cutPool,
+		root:             root,
+		nextID:           root.id + 1,
+	}
+}
+
+// startSearch runs the branch-and-bound loop across workers goroutines
+// until every node has been explored, pruned or branched, or ctx is
+// canceled, and returns the best integer-feasible solution found (nil if
+// none was, including because ctx was canceled before one turned up).
+func (t *enumerationTree) startSearch(ctx context.Context, workers int) *solution {
+	t.queue = newNodeQueue()
+	t.queue.push(t.root)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				sp, ok := t.queue.pop()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					t.queue.done()
+					continue
+				}
+				t.processNode(sp)
+			}
+		}()
+	}
+	wg.Wait()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.incumbent
+}
+
+// processNode solves sp's relaxation and either prunes it, records it as
+// (or against) the incumbent, or branches it into children pushed back
+// onto the queue. Always marks sp done with the queue exactly once, so the
+// queue can tell when the search has fully drained.
+func (t *enumerationTree) processNode(sp subProblem) {
+	defer t.queue.done()
+
+	if t.fireEvent(EventNodeSelected, &sp, 0, nil) {
+		t.instrument(sp, SUBPROBLEM_PRUNED_BY_CALLBACK)
+		return
+	}
+
+	z, x, bas, err := t.solveRelaxation(sp)
+	if err != nil {
+		if sp.id == t.root.id {
+			t.setIncumbent(&solution{err: INITIAL_RELAXATION_NOT_FEASIBLE})
+			return
+		}
+		if decision, ok := expectedFailures[err]; ok {
+			t.instrument(sp, decision)
+		}
+		return
+	}
+
+	if t.fireEvent(EventRelaxationSolved, &sp, z, x) {
+		t.instrument(sp, SUBPROBLEM_PRUNED_BY_CALLBACK)
+		return
+	}
+
+	if t.cutPool != nil && t.branchAndCut.eligible(sp.depth) {
+		z, x, bas = t.runCutRound(&sp, z, x, bas)
+		if t.fireEvent(EventCutRound, &sp, z, x) {
+			t.instrument(sp, SUBPROBLEM_PRUNED_BY_CALLBACK)
+			return
+		}
+	}
+
+	if best, ok := t.bestZ(); ok && z >= best-feasTol {
+		// With pooling on, a node within PoolOptions.Gap of the incumbent is
+		// still explored so its integer-feasible descendants can be pooled.
+		if t.pool == nil || !t.pool.opts.enabled() || !t.pool.opts.withinGap(z, best) {
+			t.instrument(sp, SUBPROBLEM_PRUNED_BY_BOUND)
+			return
+		}
+	}
+
+	if t.primalHeuristics.enabled() && t.shouldRunHeuristics(sp) {
+		t.runHeuristics(sp, x)
+	}
+
+	if feasibleForIP(sp.integralityConstraints, x) {
+		if t.fireEvent(EventIntegerFeasible, &sp, z, x) {
+			t.instrument(sp, SUBPROBLEM_PRUNED_BY_CALLBACK)
+			return
+		}
+		t.instrument(sp, SUBPROBLEM_INTEGER_FEASIBLE)
+		t.offerIncumbent(solution{x: x, z: z})
+		t.poolCandidate(sp, solution{x: x, z: z})
+		return
+	}
+
+	if t.fireEvent(EventBeforeBranch, &sp, z, x) {
+		t.instrument(sp, SUBPROBLEM_PRUNED_BY_CALLBACK)
+		return
+	}
+
+	t.instrument(sp, SUBPROBLEM_BRANCHED)
+	t.branch(sp, x, bas)
+}
+
+// fireEvent invokes t.nodeCallback, if configured, for event at sp, and
+// applies whatever it queued: lazy constraints onto the tree (effective
+// for every subproblem processed from here on), a cut onto sp itself (so
+// it's inherited by sp's children the same way a bnbConstraint from a
+// cuts.Generator would be), and a heuristic candidate offered as an
+// incumbent. Reports whether the callback pruned sp via
+// TreeContext.PruneNode.
+func (t *enumerationTree) fireEvent(event Event, sp *subProblem, relaxationZ float64, relaxationX []float64) (pruned bool) {
+	if t.nodeCallback == nil {
+		return false
+	}
+
+	tc := &treeContext{
+		node:        sp,
+		relaxationZ: relaxationZ,
+		relaxationX: relaxationX,
+		incumbent:   t.incumbentSnapshot(),
+		queueLen:    t.queue.len(),
+	}
+	t.nodeCallback(event, tc)
+
+	for _, p := range tc.lazy {
+		t.addLazyConstraint(p)
+	}
+	for _, p := range tc.cuts {
+		sp.bnbConstraints = append(sp.bnbConstraints, normalizeConstraint(p)...)
+	}
+	if tc.heuristic != nil {
+		t.offerHeuristicSolution(*sp, tc.heuristic)
+	}
+	return tc.pruned
+}
+
+// addLazyConstraint normalizes p and adds its rows to t.lazyConstraints,
+// from which effectiveGH folds them into every subproblem solved from now
+// on, per TreeContext.AddLazyConstraint's contract.
+func (t *enumerationTree) addLazyConstraint(p pendingConstraint) {
+	rows := normalizeConstraint(p)
+	t.lazyMu.Lock()
+	t.lazyConstraints = append(t.lazyConstraints, rows...)
+	t.lazyMu.Unlock()
+}
+
+// normalizeConstraint turns a callback-queued pendingConstraint into one or
+// two bnbConstraint rows (two for Equal), reusing
+// pendingConstraint.applyToInequalities rather than duplicating its
+// sense-handling.
+func normalizeConstraint(p pendingConstraint) []bnbConstraint {
+	g, h := p.applyToInequalities(nil, nil)
+	rows, n := g.Dims()
+	out := make([]bnbConstraint, rows)
+	for i := 0; i < rows; i++ {
+		a := make([]float64, n)
+		for j := 0; j < n; j++ {
+			a[j] = g.At(i, j)
+		}
+		out[i] = bnbConstraint{a: a, rhs: h[i]}
+	}
+	return out
+}
+
+// offerHeuristicSolution adopts x as the incumbent if it's integer-feasible
+// and improves on the current one, per
+// TreeContext.SetHeuristicSolution's contract.
+func (t *enumerationTree) offerHeuristicSolution(sp subProblem, x []float64) {
+	if !feasibleForIP(sp.integralityConstraints, x) {
+		return
+	}
+	var z float64
+	for i, v := range x {
+		z += sp.c[i] * v
+	}
+	t.offerIncumbent(solution{x: x, z: z})
+}
+
+// incumbentSnapshot returns the current incumbent wrapped for
+// TreeContext.Incumbent, or nil if none has been found yet.
+func (t *enumerationTree) incumbentSnapshot() *milpSolution {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.incumbent == nil {
+		return nil
+	}
+	sol := &milpSolution{solution: *t.incumbent}
+	if t.pool != nil && t.pool.opts.enabled() {
+		sol.pool = t.pool
+	}
+	return sol
+}
+
+// incumbentX returns the current incumbent's x, or nil if none has been
+// found yet, for heuristics.Context.Incumbent.
+func (t *enumerationTree) incumbentX() []float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.incumbent == nil {
+		return nil
+	}
+	return t.incumbent.x
+}
+
+// shouldRunHeuristics reports whether sp should have primalHeuristics run
+// against it: sp.depth must be within MaxDepth, and - once that's true -
+// only every Frequency-th such node actually runs them.
+func (t *enumerationTree) shouldRunHeuristics(sp subProblem) bool {
+	if !t.primalHeuristics.eligible(sp.depth) {
+		return false
+	}
+	if t.primalHeuristics.Frequency <= 1 {
+		return true
+	}
+
+	t.heuristicMu.Lock()
+	t.heuristicCount++
+	n := t.heuristicCount
+	t.heuristicMu.Unlock()
+	return n%t.primalHeuristics.Frequency == 0
+}
+
+// runHeuristics tries t.primalHeuristics.Heuristics in order against sp's
+// solved relaxation x, stopping at the first one that proposes a candidate
+// that is actually feasible for sp (not just integral - see
+// satisfiesHeuristicCandidate, since a Heuristic is free to return an
+// infeasible guess) and offering it as the incumbent.
+func (t *enumerationTree) runHeuristics(sp subProblem, x []float64) {
+	g, h := t.effectiveGH(sp)
+	hctx := heuristics.Context{
+		X:                      x,
+		IntegralityConstraints: sp.integralityConstraints,
+		LB:                     sp.lb,
+		UB:                     sp.ub,
+		G:                      g,
+		H:                      h,
+		A:                      sp.A,
+		B:                      sp.b,
+		Incumbent:              t.incumbentX(),
+		SolveSubMIP:            t.solveSubMIP,
+	}
+
+	for _, heur := range t.primalHeuristics.Heuristics {
+		cand, ok := heur.Run(hctx)
+		if !ok || !t.satisfiesHeuristicCandidate(sp, cand) {
+			continue
+		}
+
+		var z float64
+		for i, v := range cand {
+			z += sp.c[i] * v
+		}
+		t.offerIncumbent(solution{x: cand, z: z})
+		return
+	}
+}
+
+// satisfiesHeuristicCandidate reports whether x is a genuine
+// integer-feasible point of sp: every integrality constraint holds, x lies
+// within sp's lb/ub, and x satisfies sp's effective G/h and its A/b.
+func (t *enumerationTree) satisfiesHeuristicCandidate(sp subProblem, x []float64) bool {
+	if x == nil || !feasibleForIP(sp.integralityConstraints, x) {
+		return false
+	}
+	for i, v := range x {
+		if v < boundAt(sp.lb, i, defaultLB)-feasTol || v > boundAt(sp.ub, i, defaultUB)+feasTol {
+			return false
+		}
+	}
+
+	g, h := t.effectiveGH(sp)
+	if g != nil {
+		rows, n := g.Dims()
+		for i := 0; i < rows; i++ {
+			var lhs float64
+			for j := 0; j < n; j++ {
+				lhs += g.At(i, j) * x[j]
+			}
+			if lhs > h[i]+feasTol {
+				return false
+			}
+		}
+	}
+	if sp.A != nil {
+		rows, n := sp.A.Dims()
+		for i := 0; i < rows; i++ {
+			var lhs float64
+			for j := 0; j < n; j++ {
+				lhs += sp.A.At(i, j) * x[j]
+			}
+			if math.Abs(lhs-sp.b[i]) > feasTol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// solveSubMIP runs a bounded nested search over sp's own problem structure
+// narrowed to [lb, ub], for heuristics.Context.SolveSubMIP (e.g. RINS). The
+// nested tree runs with no cut generators, callback or further heuristics
+// of its own, both because that machinery isn't needed for a quick
+// sub-solve and to avoid SolveSubMIP recursing into itself. nodeBudget, if
+// <= 0, falls back to t.primalHeuristics.NodeBudget; if that's also <= 0
+// the sub-solve runs to completion uncapped.
+func (t *enumerationTree) solveSubMIP(lb, ub []float64, nodeBudget int) (x []float64, ok bool) {
+	root := t.root
+	root.lb, root.ub = lb, ub
+	root.bnbConstraints = nil
+	root.basis = nil
+	root.depth = 0
+	root.id = 0
+
+	sub := newEnumerationTree(root, nil, BranchAndCutOptions{}, nil, HeuristicOptions{}, nil)
+
+	if nodeBudget <= 0 {
+		nodeBudget = t.primalHeuristics.NodeBudget
+	}
+
+	ctx := context.Background()
+	if nodeBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+
+		var processed int32
+		sub.instrumentation = func(subProblem, bnbDecision) {
+			if int(atomic.AddInt32(&processed, 1)) >= nodeBudget {
+				cancel()
+			}
+		}
+	}
+
+	sol := sub.startSearch(ctx, 1)
+	if sol == nil || sol.err != nil {
+		return nil, false
+	}
+	return sol.x, true
+}
+
+// poolCandidate offers cand to t.pool, if pooling is configured, and - per
+// PoolOptions' own doc comment - adds a no-good cut excluding cand's exact
+// point from every subsequent subproblem, so the rest of the search spends
+// its effort on alternate optima instead of re-deriving cand again. Only
+// binary-flagged variables (integer and bounded to [0, 1]) take part in a
+// no-good cut's support (see noGoodCut); if sp has none, there's nothing a
+// no-good cut could validly exclude, so none is added.
+func (t *enumerationTree) poolCandidate(sp subProblem, cand solution) {
+	if t.pool == nil || !t.pool.opts.enabled() {
+		return
+	}
+	t.pool.Add(cand)
+
+	binary := make([]bool, len(sp.integralityConstraints))
+	anyBinary := false
+	for i, integer := range sp.integralityConstraints {
+		binary[i] = integer && boundAt(sp.lb, i, defaultLB) == 0 && boundAt(sp.ub, i, defaultUB) == 1
+		anyBinary = anyBinary || binary[i]
+	}
+	if !anyBinary {
+		return
+	}
+
+	coeffs, rhs := noGoodCut(cand.x, binary)
+	t.lazyMu.Lock()
+	t.lazyConstraints = append(t.lazyConstraints, bnbConstraint{a: coeffs, rhs: rhs})
+	t.lazyMu.Unlock()
+}
+
+// bestZ returns the current incumbent's objective value, or ok=false if no
+// incumbent has been found yet.
+func (t *enumerationTree) bestZ() (z float64, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.incumbent == nil {
+		return 0, false
+	}
+	return t.incumbent.z, true
+}
+
+func (t *enumerationTree) setIncumbent(sol *solution) {
+	t.mu.Lock()
+	t.incumbent = sol
+	t.mu.Unlock()
+}
+
+// offerIncumbent adopts sol as the incumbent if none exists yet or sol
+// improves on it.
+func (t *enumerationTree) offerIncumbent(sol solution) {
+	t.mu.Lock()
+	if t.incumbent == nil || sol.z < t.incumbent.z {
+		t.incumbent = &sol
+	}
+	t.mu.Unlock()
+}
+
+// branch splits sp on its most-fractional integer variable into a
+// round-down and a round-up child (see bounds.go's tightenUB/tightenLB),
+// both warm-startable from sp's own solved basis, and pushes them onto the
+// queue.
+func (t *enumerationTree) branch(sp subProblem, x []float64, bas basis) {
+	idx, ok := chooseBranchVar(sp, x)
+	if !ok {
+		return
+	}
+
+	upChild := sp.tightenUB(idx, x[idx])
+	upChild.boundHint = nil
+	if math.IsInf(boundAt(sp.ub, idx, defaultUB), 1) {
+		upChild.boundHint = t.boundHintFor(upChild, idx, true)
+	}
+
+	downChild := sp.tightenLB(idx, x[idx])
+	downChild.boundHint = nil
+	if boundAt(sp.lb, idx, defaultLB) <= 0 && downChild.lb[idx] > 0 {
+		downChild.boundHint = t.boundHintFor(downChild, idx, false)
+	}
+
+	for _, child := range []subProblem{upChild, downChild} {
+		child.basis = bas
+		child.depth = sp.depth + 1
+
+		t.mu.Lock()
+		child.id = t.nextID
+		t.nextID++
+		t.mu.Unlock()
+
+		t.queue.push(child)
+	}
+}
+
+// boundHintFor builds the BoundBranchHint for the Convert row of varIdx's
+// upper (wantUB) or lower bound on sp, a child whose branching has just
+// made that row appear, locating it the same way buildStandardForm's own
+// call to Convert will: past sp's effective structural-and-cut rows, at
+// the position boundRowPosition assigns it among sp's finite bounds.
+func (t *enumerationTree) boundHintFor(sp subProblem, varIdx int, wantUB bool) *BoundBranchHint {
+	_, structH := t.effectiveGH(sp)
+	baseRows := len(structH)
+
+	aRows := 0
+	if sp.A != nil {
+		aRows, _ = sp.A.Dims()
+	}
+
+	n := len(sp.c)
+	rowIdx := boundRowPosition(varIdx, wantUB, baseRows, n, sp.lb, sp.ub)
+	return &BoundBranchHint{Pos: aRows + rowIdx, SlackCol: n + rowIdx}
+}
+
+// chooseBranchVar picks the integrality-constrained variable to branch on:
+// the most-fractional one, farthest from its own rounding among x's
+// integer-constrained components. Reports ok=false if every such
+// component is already integral (feasibleForIP should have caught this
+// first).
+func chooseBranchVar(sp subProblem, x []float64) (int, bool) {
+	best, bestDist := -1, feasTol
+	for i, integer := range sp.integralityConstraints {
+		if !integer {
+			continue
+		}
+		if d := math.Abs(x[i] - math.Round(x[i])); d > bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best, best != -1
+}
+
+// instrument calls t.instrumentation, if any was configured.
+func (t *enumerationTree) instrument(sp subProblem, decision bnbDecision) {
+	if t.instrumentation != nil {
+		t.instrumentation(sp, decision)
+	}
+}
+
+// effectiveGH folds sp's bnbConstraints onto its structural G/h, producing
+// the inequality system solveRelaxation actually solves against. Kept
+// separate from sp.G/h so appending a lazy constraint or a cut never
+// mutates a node's ancestors' matrices.
+func (t *enumerationTree) effectiveGH(sp subProblem) (*mat.Dense, []float64) {
+	g, h := sp.G, sp.h
+	for _, bc := range sp.bnbConstraints {
+		g, h = appendRow(g, h, bc.a, bc.rhs)
+	}
+
+	t.lazyMu.Lock()
+	lazy := append([]bnbConstraint{}, t.lazyConstraints...)
+	t.lazyMu.Unlock()
+	for _, bc := range lazy {
+		g, h = appendRow(g, h, bc.a, bc.rhs)
+	}
+	return g, h
+}
+
+// runCutRound runs t.branchAndCut.Generators once against sp's solved
+// relaxation, pools whatever new rows they return, appends every row
+// currently in the pool (sp's own new ones and any reusable ancestor cuts)
+// to sp.bnbConstraints, and re-solves sp's relaxation against the
+// tightened system. Rows that come back non-binding at the re-solved
+// vertex are left for Purge to reap once they've been idle too long;
+// anything still tight is Touch-ed to stay alive. Falls back to the
+// pre-round (z, x, bas) if the tableau can't be built or the re-solve
+// fails, leaving sp's G/h exactly as it was.
+func (t *enumerationTree) runCutRound(sp *subProblem, z float64, x []float64, bas basis) (float64, []float64, basis) {
+	tab, ok := t.buildTableau(*sp, bas)
+	if !ok {
+		return z, x, bas
+	}
+
+	g, h := t.effectiveGH(*sp)
+	cutCtx := cuts.Context{
+		Tab:                    tab,
+		IntegralityConstraints: sp.integralityConstraints,
+		X:                      x,
+		G:                      g,
+		H:                      h,
+		LB:                     sp.lb,
+		UB:                     sp.ub,
+	}
+	for _, gen := range t.branchAndCut.Generators {
+		for _, row := range gen.Generate(cutCtx) {
+			t.cutPool.Add(row, gen.Name(), sp.id)
+		}
+	}
+
+	entries := t.cutPool.Entries()
+	base := len(sp.bnbConstraints)
+	for _, e := range entries {
+		sp.bnbConstraints = append(sp.bnbConstraints, bnbConstraint{a: e.Coeffs, rhs: e.RHS})
+	}
+
+	newZ, newX, newBas, err := t.solveRelaxation(*sp)
+	if err != nil {
+		sp.bnbConstraints = sp.bnbConstraints[:base]
+		return z, x, bas
+	}
+
+	for i, e := range entries {
+		a := sp.bnbConstraints[base+i].a
+		var lhs float64
+		for j, coef := range a {
+			lhs += coef * newX[j]
+		}
+		if math.Abs(lhs-e.RHS) <= feasTol {
+			t.cutPool.Touch(e.ID, sp.id)
+		}
+	}
+	t.cutPool.Purge(sp.id)
+
+	return newZ, newX, newBas
+}
+
+// buildTableau reconstructs the simplex tableau a cuts.Generator needs
+// (Binv*A and Binv*b for sp's standard form, against basis bas) by
+// inverting bas's basis matrix directly; lp.Simplex itself doesn't expose
+// the tableau it pivoted to.
+func (t *enumerationTree) buildTableau(sp subProblem, bas basis) (cuts.Tableau, bool) {
+	A, b, _, ok := t.buildStandardForm(sp)
+	if !ok {
+		return cuts.Tableau{}, false
+	}
+
+	ab := basisMatrix(A, []int(bas))
+	var abInv mat.Dense
+	if err := abInv.Inverse(ab); err != nil {
+		return cuts.Tableau{}, false
+	}
+
+	var binvA mat.Dense
+	binvA.Mul(&abInv, A)
+
+	m, _ := A.Dims()
+	var binvBVec mat.VecDense
+	binvBVec.MulVec(&abInv, mat.NewVecDense(m, b))
+	binvB := make([]float64, m)
+	for i := 0; i < m; i++ {
+		binvB[i] = binvBVec.AtVec(i)
+	}
+
+	return cuts.Tableau{Basis: []int(bas), BinvA: &binvA, BinvB: binvB}, true
+}
+
+// buildStandardForm folds sp's A/b, effective G/h and lb/ub into a single
+// equality system (A, b) suitable for lp.Simplex: minimize c^T x' s.t.
+// A x' = b, x' >= 0, where x = x' + shift undoes the variable substitution
+// Convert applies for a negative lower bound (see bounds.go's Convert). ok
+// is false if any variable's lower bound is -Inf.
+func (t *enumerationTree) buildStandardForm(sp subProblem) (A *mat.Dense, b, shift []float64, ok bool) {
+	structG, structH := t.effectiveGH(sp)
+	g, hh, shift, ok := Convert(len(sp.c), structG, structH, sp.lb, sp.ub)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	n := len(sp.c)
+	gRows := 0
+	if g != nil {
+		gRows, _ = g.Dims()
+	}
+	aRows := 0
+	if sp.A != nil {
+		aRows, _ = sp.A.Dims()
+	}
+
+	full := mat.NewDense(aRows+gRows, n+gRows, nil)
+	bFull := make([]float64, aRows+gRows)
+
+	shiftedB := ShiftRHS(sp.A, sp.b, shift)
+	for r := 0; r < aRows; r++ {
+		for j := 0; j < n; j++ {
+			full.Set(r, j, sp.A.At(r, j))
+		}
+		bFull[r] = shiftedB[r]
+	}
+	for r := 0; r < gRows; r++ {
+		for j := 0; j < n; j++ {
+			full.Set(aRows+r, j, g.At(r, j))
+		}
+		full.Set(aRows+r, n+r, 1) // slack
+		bFull[aRows+r] = hh[r]
+	}
+
+	return full, bFull, shift, true
+}
+
+// solveRelaxation solves sp's LP relaxation and returns its true objective
+// (shift-corrected, see buildStandardForm), its solution in the original
+// variables, and the standard-form basis a child can warm-start from, or
+// the error lp.Simplex failed with.
+func (t *enumerationTree) solveRelaxation(sp subProblem) (z float64, x []float64, bas basis, err error) {
+	A, b, shift, ok := t.buildStandardForm(sp)
+	if !ok {
+		return 0, nil, nil, lp.ErrInfeasible
+	}
+
+	zStd, xStd, bas, err := solveRelaxationWarm(sp.c, A, b, sp.basis, sp.boundHint)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	n := len(sp.c)
+	x = make([]float64, n)
+	var shiftDot float64
+	for i := 0; i < n; i++ {
+		x[i] = xStd[i] + shift[i]
+		shiftDot += sp.c[i] * shift[i]
+	}
+	return zStd + shiftDot, x, bas, nil
+}
+
+// nodeQueue is a LIFO (depth-first) work queue shared by startSearch's
+// worker goroutines. pop blocks while the queue is empty but pending work
+// remains (either queued elsewhere or still being processed by another
+// worker), and returns ok=false once every pushed node has been popped and
+// marked done.
+type nodeQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	nodes   []subProblem
+	pending int
+}
+
+func newNodeQueue() *nodeQueue {
+	q := &nodeQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// len reports how many nodes are currently waiting in the queue, for
+// TreeContext.QueueLen.
+func (q *nodeQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.nodes)
+}
+
+func (q *nodeQueue) push(sp subProblem) {
+	q.mu.Lock()
+	q.nodes = append(q.nodes, sp)
+	q.pending++
+	q.cond.Broadcast()
+	q.mu.Unlock()
+}
+
+func (q *nodeQueue) pop() (subProblem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.nodes) == 0 {
+		if q.pending == 0 {
+			return subProblem{}, false
+		}
+		q.cond.Wait()
+	}
+	sp := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return sp, true
+}
+
+// done marks one popped node as fully processed (pruned, resolved against
+// the incumbent, or branched into children that were pushed back before
+// this call), waking any worker blocked in pop waiting to see the queue
+// drain.
+func (q *nodeQueue) done() {
+	q.mu.Lock()
+	q.pending--
+	if q.pending == 0 {
+		q.cond.Broadcast()
+	}
+	q.mu.Unlock()
+}
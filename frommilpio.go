@@ -0,0 +1,56 @@
+package ilp
+
+import "github.com/rlacjfjin/GoMILP/milpio"
+
+// Options bundles the search knobs milpProblem stores, exported so a
+// caller building a problem from parsed file data can configure
+// branch-and-cut, primal heuristics, pooling, branching and a node
+// callback without needing access to milpProblem's unexported fields.
+// The zero value disables cuts, heuristics and pooling, matching
+// milpProblem's own zero-value behavior of plain branch-and-bound.
+type Options struct {
+	BranchingHeuristic BranchHeuristic
+	BranchAndCut       BranchAndCutOptions
+	NodeCallback       NodeCallback
+	PrimalHeuristics   HeuristicOptions
+	Pool               PoolOptions
+}
+
+// FromMILPIO converts a milpio.Problem - the result of milpio.ReadLP or
+// milpio.ReadMPS - into the shape the search actually consumes. Without
+// this, a file parsed through milpio had nowhere to go: nothing in this
+// package built a milpProblem out of already-parsed data, so a round trip
+// from an MPS/LP file to a solved instance couldn't be demonstrated.
+//
+// milpProblem has no notion of objective sense at all; it always
+// minimizes c. If p.Sense is milpio.Maximize, FromMILPIO negates c -
+// maximizing c^T x is the same problem as minimizing -c^T x over the same
+// feasible region - and returns maximized=true so the caller can negate
+// the reported objective value back to recover the original maximum; the
+// solution vector x itself needs no adjustment.
+func FromMILPIO(p *milpio.Problem, opts Options) (problem milpProblem, maximized bool) {
+	c := p.C
+	if p.Sense == milpio.Maximize {
+		maximized = true
+		c = make([]float64, len(p.C))
+		for i, v := range p.C {
+			c[i] = -v
+		}
+	}
+
+	return milpProblem{
+		c:                      c,
+		A:                      p.A,
+		b:                      p.B,
+		G:                      p.G,
+		h:                      p.H,
+		integralityConstraints: p.Integrality,
+		lb:                     p.LB,
+		ub:                     p.UB,
+		branchingHeuristic:     opts.BranchingHeuristic,
+		branchAndCut:           opts.BranchAndCut,
+		nodeCallback:           opts.NodeCallback,
+		primalHeuristics:       opts.PrimalHeuristics,
+		pool:                   opts.Pool,
+	}, maximized
+}
@@ -0,0 +1,70 @@
+package ilp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rlacjfjin/GoMILP/milpio"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestFromMILPIO_CopiesNumericalShape(t *testing.T) {
+	g := mat.NewDense(1, 2, []float64{1, 1})
+	p := &milpio.Problem{
+		C:           []float64{1, 2},
+		G:           g,
+		H:           []float64{10},
+		Integrality: []bool{true, false},
+		LB:          []float64{0, 0},
+		UB:          []float64{5, 5},
+	}
+
+	got, maximized := FromMILPIO(p, Options{})
+	if maximized {
+		t.Fatalf("expected maximized=false for the default Minimize sense")
+	}
+
+	if !reflect.DeepEqual(got.c, p.C) {
+		t.Fatalf("c = %v, want %v", got.c, p.C)
+	}
+	if got.G != p.G {
+		t.Fatalf("G was copied instead of referenced")
+	}
+	if !reflect.DeepEqual(got.integralityConstraints, p.Integrality) {
+		t.Fatalf("integralityConstraints = %v, want %v", got.integralityConstraints, p.Integrality)
+	}
+	if !reflect.DeepEqual(got.lb, p.LB) || !reflect.DeepEqual(got.ub, p.UB) {
+		t.Fatalf("bounds = [%v, %v], want [%v, %v]", got.lb, got.ub, p.LB, p.UB)
+	}
+}
+
+func TestFromMILPIO_AppliesOptions(t *testing.T) {
+	p := &milpio.Problem{C: []float64{1}, Integrality: []bool{false}}
+	opts := Options{Pool: PoolOptions{Gap: 0.1}}
+
+	got, _ := FromMILPIO(p, opts)
+	if got.pool.Gap != 0.1 {
+		t.Fatalf("pool.Gap = %v, want 0.1", got.pool.Gap)
+	}
+}
+
+// TestFromMILPIO_NegatesMaximizeObjective covers the case milpProblem can't
+// represent on its own: it always minimizes c, so a Maximize problem must
+// have c negated (and maximized reported) rather than silently solved as
+// the wrong sense.
+func TestFromMILPIO_NegatesMaximizeObjective(t *testing.T) {
+	p := &milpio.Problem{
+		Sense:       milpio.Maximize,
+		C:           []float64{1, -2},
+		Integrality: []bool{false, false},
+	}
+
+	got, maximized := FromMILPIO(p, Options{})
+	if !maximized {
+		t.Fatalf("expected maximized=true for a Maximize problem")
+	}
+	want := []float64{-1, 2}
+	if !reflect.DeepEqual(got.c, want) {
+		t.Fatalf("c = %v, want %v (negated)", got.c, want)
+	}
+}
@@ -0,0 +1,104 @@
+// Package heuristics looks for early incumbents from a node's fractional LP
+// relaxation, the way FeasibilityPump rounds-and-re-solves towards an
+// integral vertex or RINS restricts the search to the neighborhood of an
+// existing incumbent. A candidate is never adopted outright: the caller
+// re-checks it against the problem's actual constraints (or, for a
+// heuristic that uses Context.SolveSubMIP, re-solves it properly), since a
+// Heuristic is free to return an infeasible guess.
+package heuristics
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Source identifies which heuristic produced a candidate.
+type Source string
+
+const (
+	SourcePump Source = "feasibility-pump"
+	SourceRINS Source = "rins"
+)
+
+// Context is everything a Heuristic needs to look at a solved LP relaxation
+// and propose an integer-feasible candidate.
+type Context struct {
+	X                      []float64 // relaxation solution, length len(IntegralityConstraints)
+	IntegralityConstraints []bool
+	LB, UB                 []float64
+
+	// G, H and A, B are the node's current constraint rows, including any
+	// prior cuts and callback-added rows (branching bounds are in LB/UB,
+	// not here), needed to check whether a proposed
+	// candidate is actually feasible rather than just integral.
+	G *mat.Dense
+	H []float64
+	A *mat.Dense
+	B []float64
+
+	// Incumbent is the best integer-feasible solution found so far, or nil
+	// if none has been found yet. RINS has nothing to do without one.
+	Incumbent []float64
+
+	// SolveSubMIP, when non-nil, lets a heuristic hand a restricted
+	// sub-problem back to the caller's own search instead of only
+	// proposing a rounded point for the caller to verify. lb/ub are the
+	// sub-MIP's tightened bounds (e.g. RINS fixing every variable that
+	// agrees with the incumbent); nodeBudget caps how many nodes the
+	// caller's search may explore before giving up. ok is false if the
+	// sub-MIP wasn't solved to an integer-feasible point within budget.
+	// Nil means no sub-MIP solver is wired in, e.g. because the caller's
+	// own search is already inside a sub-MIP solve of its own.
+	SolveSubMIP func(lb, ub []float64, nodeBudget int) (x []float64, ok bool)
+}
+
+// Heuristic proposes a candidate integer-feasible solution from a solved LP
+// relaxation. Implementations must be side-effect free: Run may be called
+// once per node per round, and nodes may be explored concurrently.
+type Heuristic interface {
+	Name() Source
+	Run(ctx Context) (x []float64, ok bool)
+}
+
+// feasTol is the tolerance used both for treating a relaxation value as
+// integral and for checking a proposed candidate against G/h and A/b.
+const feasTol = 1e-6
+
+// roundClamped rounds v to the nearest integer and clamps it into [lb, ub].
+func roundClamped(v, lb, ub float64) float64 {
+	v = math.Round(v)
+	if v < lb {
+		v = math.Ceil(lb)
+	}
+	if v > ub {
+		v = math.Floor(ub)
+	}
+	return v
+}
+
+// feasible reports whether x satisfies every row of G*x <= h and A*x = b,
+// within feasTol.
+func feasible(ctx Context, x []float64) bool {
+	if ctx.G != nil {
+		rows, _ := ctx.G.Dims()
+		for i := 0; i < rows; i++ {
+			if mat.Dot(ctx.G.RowView(i), vec(x)) > ctx.H[i]+feasTol {
+				return false
+			}
+		}
+	}
+	if ctx.A != nil {
+		rows, _ := ctx.A.Dims()
+		for i := 0; i < rows; i++ {
+			if math.Abs(mat.Dot(ctx.A.RowView(i), vec(x))-ctx.B[i]) > feasTol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func vec(x []float64) mat.Vector {
+	return mat.NewVecDense(len(x), x)
+}
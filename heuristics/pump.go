@@ -0,0 +1,287 @@
+package heuristics
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// FeasibilityPump implements the classical feasibility pump: round the LP
+// relaxation to the nearest integer point, then re-solve the relaxation
+// with an objective that minimizes the (linearized) L1 distance to that
+// rounding, alternating the two until a re-solved relaxation is itself
+// integral (an integer-feasible point) or MaxIters is exhausted. A cycle -
+// the projection rounding to the same point twice in a row - is broken by
+// perturbing the rounding, flipping the most-fractional variables to their
+// other side, the same recovery the original pump paper uses.
+type FeasibilityPump struct {
+	// MaxIters bounds how many round/re-solve cycles are attempted before
+	// giving up on a node. Zero means a single rounding pass with no
+	// re-solve.
+	MaxIters int
+}
+
+func (FeasibilityPump) Name() Source { return SourcePump }
+
+func (fp FeasibilityPump) Run(ctx Context) ([]float64, bool) {
+	x := append([]float64{}, ctx.X...)
+
+	var lastRounding []float64
+	for iter := 0; iter < fp.MaxIters; iter++ {
+		rounded := roundIntegers(ctx, x)
+		if integerFeasible(ctx, rounded) {
+			return rounded, true
+		}
+
+		if lastRounding != nil && sameRounding(rounded, lastRounding, ctx.IntegralityConstraints) {
+			rounded = perturb(ctx, rounded, x)
+		}
+		lastRounding = rounded
+
+		projected, ok := solveL1Projection(ctx, rounded)
+		if !ok {
+			break
+		}
+		x = projected
+	}
+
+	final := roundIntegers(ctx, x)
+	return final, integerFeasible(ctx, final)
+}
+
+// roundIntegers returns a copy of x with every integrality-constrained
+// component rounded to the nearest integer and clamped to its bounds;
+// continuous components are left untouched.
+func roundIntegers(ctx Context, x []float64) []float64 {
+	out := append([]float64{}, x...)
+	for i, integer := range ctx.IntegralityConstraints {
+		if integer {
+			out[i] = roundClamped(x[i], boundAt(ctx.LB, i, 0), boundAt(ctx.UB, i, math.Inf(1)))
+		}
+	}
+	return out
+}
+
+// integerFeasible reports whether every integrality-constrained component
+// of x is already an integer and x satisfies the node's actual
+// constraints.
+func integerFeasible(ctx Context, x []float64) bool {
+	for i, integer := range ctx.IntegralityConstraints {
+		if integer && math.Abs(x[i]-math.Round(x[i])) > feasTol {
+			return false
+		}
+	}
+	return feasible(ctx, x)
+}
+
+// sameRounding reports whether a and b agree on every integrality
+// -constrained component, the condition a stalled round/re-solve cycle
+// (repeatedly rounding to the same point) is detected by.
+func sameRounding(a, b []float64, integrality []bool) bool {
+	for i, integer := range integrality {
+		if integer && a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// perturb breaks a stalled rounding by flipping the most-fractional
+// integer components (measured against the unrounded relaxation x) to
+// their other side, half of them at a time, the same recovery step the
+// original feasibility pump paper uses when the round/re-solve cycle
+// repeats a rounding it has already tried.
+func perturb(ctx Context, rounded, x []float64) []float64 {
+	type frac struct {
+		idx  int
+		dist float64
+	}
+	var fracs []frac
+	for i, integer := range ctx.IntegralityConstraints {
+		if !integer {
+			continue
+		}
+		d := math.Abs(x[i] - math.Round(x[i]))
+		if d > feasTol {
+			fracs = append(fracs, frac{i, d})
+		}
+	}
+	if len(fracs) == 0 {
+		return rounded
+	}
+	sort.Slice(fracs, func(a, b int) bool { return fracs[a].dist > fracs[b].dist })
+
+	flip := len(fracs) / 2
+	if flip < 1 {
+		flip = 1
+	}
+
+	out := append([]float64{}, rounded...)
+	for _, f := range fracs[:flip] {
+		lb, ub := boundAt(ctx.LB, f.idx, 0), boundAt(ctx.UB, f.idx, math.Inf(1))
+		step := 1.0
+		if out[f.idx] > x[f.idx] {
+			step = -1
+		}
+		out[f.idx] = math.Min(ub, math.Max(lb, out[f.idx]+step))
+	}
+	return out
+}
+
+// solveL1Projection re-solves the node's relaxation with the objective
+// replaced by min sum_i |x_i - target_i| over integrality-constrained i,
+// the linearized projection step of the feasibility pump. It builds a
+// fresh standard-form LP out of ctx's A/b, G/h and bounds (see
+// buildStandardForm) plus, for each integer variable i, a distance column
+// d_i and the pair of rows d_i >= x_i - target_i, d_i >= target_i - x_i
+// that make minimizing sum d_i equivalent to minimizing the L1 distance.
+func solveL1Projection(ctx Context, target []float64) ([]float64, bool) {
+	n := len(ctx.IntegralityConstraints)
+	base, b, shift, ok := buildStandardForm(ctx)
+	if !ok {
+		return nil, false
+	}
+	baseRows, baseCols := base.Dims()
+
+	var integerIdx []int
+	for i, integer := range ctx.IntegralityConstraints {
+		if integer {
+			integerIdx = append(integerIdx, i)
+		}
+	}
+	k := len(integerIdx)
+
+	totalCols := baseCols + 3*k // k distance columns + 2k row-slack columns
+	totalRows := baseRows + 2*k
+
+	full := mat.NewDense(totalRows, totalCols, nil)
+	bFull := make([]float64, totalRows)
+	for r := 0; r < baseRows; r++ {
+		for col := 0; col < baseCols; col++ {
+			full.Set(r, col, base.At(r, col))
+		}
+		bFull[r] = b[r]
+	}
+
+	c := make([]float64, totalCols)
+	for t, i := range integerIdx {
+		dCol := baseCols + t
+		c[dCol] = 1
+		adjustedTarget := target[i] - shift[i]
+
+		// d_i - x_i >= -target_i  =>  x_i - d_i <= target_i
+		row1 := baseRows + 2*t
+		full.Set(row1, i, 1)
+		full.Set(row1, dCol, -1)
+		full.Set(row1, baseCols+k+2*t, 1)
+		bFull[row1] = adjustedTarget
+
+		// d_i + x_i >= target_i  =>  -x_i - d_i <= -target_i
+		row2 := row1 + 1
+		full.Set(row2, i, -1)
+		full.Set(row2, dCol, -1)
+		full.Set(row2, baseCols+k+2*t+1, 1)
+		bFull[row2] = -adjustedTarget
+	}
+
+	_, xStd, err := lp.Simplex(c, full, bFull, 0, nil)
+	if err != nil {
+		return nil, false
+	}
+
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = xStd[i] + shift[i]
+	}
+	return x, true
+}
+
+// buildStandardForm folds ctx's A/b, G/h, and LB/UB into a single equality
+// system (A, b) suitable for lp.Simplex (minimize c^T x s.t. A x = b,
+// x >= 0): every G row gains its own slack column, and a finite lower
+// bound lb != 0 shifts that column (x = x' + lb, x' >= 0, reported back in
+// shift) while a finite upper bound becomes an extra row. A variable with
+// lb = -Inf (free) isn't representable this way - lp.Simplex has no notion
+// of a variable without a lower bound - so buildStandardForm reports
+// ok=false rather than silently mishandling it.
+func buildStandardForm(ctx Context) (A *mat.Dense, b, shift []float64, ok bool) {
+	n := len(ctx.IntegralityConstraints)
+	shift = make([]float64, n)
+	for i := 0; i < n; i++ {
+		lb := boundAt(ctx.LB, i, 0)
+		if math.IsInf(lb, -1) {
+			return nil, nil, nil, false
+		}
+		shift[i] = lb
+	}
+
+	g, h := ctx.G, append([]float64{}, ctx.H...)
+	for i := 0; i < n; i++ {
+		if ub := boundAt(ctx.UB, i, math.Inf(1)); !math.IsInf(ub, 1) {
+			row := make([]float64, n)
+			row[i] = 1
+			g, h = appendIneqRow(g, h, row, ub-shift[i])
+		}
+	}
+
+	gRows := 0
+	if g != nil {
+		gRows, _ = g.Dims()
+	}
+	aRows := 0
+	if ctx.A != nil {
+		aRows, _ = ctx.A.Dims()
+	}
+
+	totalCols := n + gRows
+	full := mat.NewDense(aRows+gRows, totalCols, nil)
+	bFull := make([]float64, aRows+gRows)
+
+	for r := 0; r < aRows; r++ {
+		var shiftDot float64
+		for j := 0; j < n; j++ {
+			v := ctx.A.At(r, j)
+			full.Set(r, j, v)
+			shiftDot += v * shift[j]
+		}
+		bFull[r] = ctx.B[r] - shiftDot
+	}
+	for r := 0; r < gRows; r++ {
+		var shiftDot float64
+		for j := 0; j < n; j++ {
+			v := g.At(r, j)
+			full.Set(aRows+r, j, v)
+			shiftDot += v * shift[j]
+		}
+		full.Set(aRows+r, n+r, 1) // slack
+		bFull[aRows+r] = h[r] - shiftDot
+	}
+
+	return full, bFull, shift, true
+}
+
+// appendIneqRow grows g by one row [row] and h by one entry [rhs]; used to
+// fold a finite upper bound into an extra <= row the same way ilp's
+// Convert does.
+func appendIneqRow(g *mat.Dense, h []float64, row []float64, rhs float64) (*mat.Dense, []float64) {
+	rows, cols := 0, len(row)
+	if g != nil {
+		rows, cols = g.Dims()
+	}
+	grown := mat.NewDense(rows+1, cols, nil)
+	if g != nil {
+		grown.Copy(g)
+	}
+	grown.SetRow(rows, row)
+	return grown, append(append([]float64{}, h...), rhs)
+}
+
+// boundAt returns bounds[i], or def if bounds is nil/too short.
+func boundAt(bounds []float64, i int, def float64) float64 {
+	if i >= len(bounds) {
+		return def
+	}
+	return bounds[i]
+}
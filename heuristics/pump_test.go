@@ -0,0 +1,103 @@
+package heuristics
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestRoundIntegers_ClampsToBounds(t *testing.T) {
+	ctx := Context{
+		IntegralityConstraints: []bool{true, false, true},
+		LB:                     []float64{0, 0, 0},
+		UB:                     []float64{1, 1, 1},
+	}
+	got := roundIntegers(ctx, []float64{0.9, 0.4, 1.4})
+	want := []float64{1, 0.4, 1}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("roundIntegers(%v) = %v, want %v", ctx, got, want)
+		}
+	}
+}
+
+func TestSameRounding(t *testing.T) {
+	integrality := []bool{true, false, true}
+	a := []float64{1, 0.3, 0}
+	b := []float64{1, 0.9, 0}
+	if !sameRounding(a, b, integrality) {
+		t.Fatalf("expected sameRounding to ignore the continuous component")
+	}
+	c := []float64{0, 0.3, 0}
+	if sameRounding(a, c, integrality) {
+		t.Fatalf("expected sameRounding to detect the differing integer component")
+	}
+}
+
+func TestPerturb_FlipsMostFractional(t *testing.T) {
+	ctx := Context{
+		IntegralityConstraints: []bool{true, true, true},
+		LB:                     []float64{0, 0, 0},
+		UB:                     []float64{1, 1, 1},
+	}
+	x := []float64{0.95, 0.5, 0.1}
+	rounded := roundIntegers(ctx, x)
+
+	got := perturb(ctx, rounded, x)
+	if got[1] != rounded[1]-1 {
+		t.Fatalf("expected the most-fractional var (idx 1) to flip, got %v", got)
+	}
+}
+
+// TestFeasibilityPump_Run_StopsOnIntegralStart covers the trivial case: an
+// already-integral relaxation needs no re-solve.
+func TestFeasibilityPump_Run_StopsOnIntegralStart(t *testing.T) {
+	ctx := Context{
+		X:                      []float64{1, 0},
+		IntegralityConstraints: []bool{true, true},
+		LB:                     []float64{0, 0},
+		UB:                     []float64{1, 1},
+	}
+	x, ok := (FeasibilityPump{MaxIters: 5}).Run(ctx)
+	if !ok {
+		t.Fatalf("expected an integer-feasible candidate")
+	}
+	if x[0] != 1 || x[1] != 0 {
+		t.Fatalf("got %v, want [1 0]", x)
+	}
+}
+
+// TestFeasibilityPump_Run_ConvergesOnSimpleRelaxation covers a fractional
+// start whose first rounding is already feasible: x=0.5 with x<=1,
+// 0<=x<=1, x binary, rounds to 1 on the first pass, which satisfies the
+// row, so Run returns it without any L1-projection re-solve.
+func TestFeasibilityPump_Run_ConvergesOnSimpleRelaxation(t *testing.T) {
+	g := mat.NewDense(1, 1, []float64{1})
+	ctx := Context{
+		X:                      []float64{0.5},
+		IntegralityConstraints: []bool{true},
+		LB:                     []float64{0},
+		UB:                     []float64{1},
+		G:                      g,
+		H:                      []float64{1},
+	}
+	x, ok := (FeasibilityPump{MaxIters: 5}).Run(ctx)
+	if !ok {
+		t.Fatalf("expected an integer-feasible candidate, got x=%v", x)
+	}
+	if math.Abs(x[0]-math.Round(x[0])) > 1e-9 {
+		t.Fatalf("expected an integral candidate, got %v", x)
+	}
+}
+
+func TestBuildStandardForm_RejectsFreeVariable(t *testing.T) {
+	ctx := Context{
+		IntegralityConstraints: []bool{true},
+		LB:                     []float64{math.Inf(-1)},
+		UB:                     []float64{1},
+	}
+	if _, _, _, ok := buildStandardForm(ctx); ok {
+		t.Fatalf("expected buildStandardForm to reject a variable with lb=-Inf")
+	}
+}
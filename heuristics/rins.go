@@ -0,0 +1,78 @@
+package heuristics
+
+import "math"
+
+// RINS (Relaxation Induced Neighborhood Search) looks at where the current
+// LP relaxation agrees with the incumbent, fixes those integer variables at
+// the incumbent's values, and hands the resulting restricted sub-MIP to
+// Context.SolveSubMIP - the idea being that the neighborhood of points
+// agreeing with a good incumbent is small and likely to contain a better
+// one. If the caller hasn't wired a sub-MIP solver in, RINS falls back to
+// proposing the fixed-and-rounded point directly, leaving it to the caller
+// to verify feasibility.
+type RINS struct {
+	// AgreeTol is how close X[i] and Incumbent[i] must be to be considered
+	// in agreement. Zero means require an exact match.
+	AgreeTol float64
+
+	// NodeBudget caps the sub-MIP solve kicked off through
+	// Context.SolveSubMIP. Zero defers to the caller's own default.
+	NodeBudget int
+}
+
+func (RINS) Name() Source { return SourceRINS }
+
+func (r RINS) Run(ctx Context) ([]float64, bool) {
+	if ctx.Incumbent == nil {
+		return nil, false
+	}
+
+	n := len(ctx.IntegralityConstraints)
+	lb := growBounds(ctx.LB, n, 0)
+	ub := growBounds(ctx.UB, n, math.Inf(1))
+
+	var fixedAny bool
+	for i, integer := range ctx.IntegralityConstraints {
+		if !integer {
+			continue
+		}
+		if math.Abs(ctx.X[i]-ctx.Incumbent[i]) <= r.AgreeTol {
+			lb[i], ub[i] = ctx.Incumbent[i], ctx.Incumbent[i]
+			fixedAny = true
+		}
+	}
+	if !fixedAny {
+		// Nothing to restrict: the relaxation disagrees with the incumbent
+		// everywhere, so the "neighborhood" is the whole problem again.
+		return nil, false
+	}
+
+	if ctx.SolveSubMIP != nil {
+		return ctx.SolveSubMIP(lb, ub, r.NodeBudget)
+	}
+
+	x := append([]float64{}, ctx.X...)
+	for i, integer := range ctx.IntegralityConstraints {
+		if !integer {
+			continue
+		}
+		if lb[i] == ub[i] {
+			x[i] = lb[i]
+		} else {
+			x[i] = roundClamped(x[i], boundAt(ctx.LB, i, 0), boundAt(ctx.UB, i, math.Inf(1)))
+		}
+	}
+	if !feasible(ctx, x) {
+		return nil, false
+	}
+	return x, true
+}
+
+// growBounds returns a copy of bounds padded with def up to length n.
+func growBounds(bounds []float64, n int, def float64) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = boundAt(bounds, i, def)
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package heuristics
+
+import "testing"
+
+func TestRINS_NoIncumbent(t *testing.T) {
+	ctx := Context{IntegralityConstraints: []bool{true}}
+	if _, ok := (RINS{}).Run(ctx); ok {
+		t.Fatalf("expected RINS to decline without an incumbent")
+	}
+}
+
+func TestRINS_UsesSolveSubMIPWhenWired(t *testing.T) {
+	var gotLB, gotUB []float64
+	var gotBudget int
+	ctx := Context{
+		X:                      []float64{1, 2.3},
+		Incumbent:              []float64{1, 5},
+		IntegralityConstraints: []bool{true, true},
+		LB:                     []float64{0, 0},
+		UB:                     []float64{10, 10},
+		SolveSubMIP: func(lb, ub []float64, nodeBudget int) ([]float64, bool) {
+			gotLB, gotUB, gotBudget = lb, ub, nodeBudget
+			return []float64{1, 5}, true
+		},
+	}
+
+	x, ok := (RINS{AgreeTol: 1e-6, NodeBudget: 50}).Run(ctx)
+	if !ok {
+		t.Fatalf("expected RINS to propose a candidate")
+	}
+	if x[0] != 1 || x[1] != 5 {
+		t.Fatalf("got %v from SolveSubMIP passthrough, want [1 5]", x)
+	}
+	if gotLB[0] != 1 || gotUB[0] != 1 {
+		t.Fatalf("expected variable 0 (agreeing with incumbent) fixed, got lb=%v ub=%v", gotLB, gotUB)
+	}
+	if gotLB[1] != 0 || gotUB[1] != 10 {
+		t.Fatalf("expected variable 1 (disagreeing) left unfixed, got lb=%v ub=%v", gotLB, gotUB)
+	}
+	if gotBudget != 50 {
+		t.Fatalf("expected NodeBudget 50 passed through, got %d", gotBudget)
+	}
+}
+
+func TestRINS_FallsBackToRoundingWithoutSolveSubMIP(t *testing.T) {
+	ctx := Context{
+		X:                      []float64{1, 2.3},
+		Incumbent:              []float64{1, 5},
+		IntegralityConstraints: []bool{true, true},
+		LB:                     []float64{0, 0},
+		UB:                     []float64{10, 10},
+	}
+	x, ok := (RINS{AgreeTol: 1e-6}).Run(ctx)
+	if !ok {
+		t.Fatalf("expected a fallback candidate")
+	}
+	if x[0] != 1 {
+		t.Fatalf("expected the fixed variable to use the incumbent value, got %v", x)
+	}
+}
+
+func TestRINS_NoAgreementDeclines(t *testing.T) {
+	ctx := Context{
+		X:                      []float64{2.3, 7.1},
+		Incumbent:              []float64{1, 5},
+		IntegralityConstraints: []bool{true, true},
+		LB:                     []float64{0, 0},
+		UB:                     []float64{10, 10},
+	}
+	if _, ok := (RINS{AgreeTol: 1e-6}).Run(ctx); ok {
+		t.Fatalf("expected RINS to decline when nothing agrees with the incumbent")
+	}
+}
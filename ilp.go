@@ -21,13 +21,47 @@ type milpProblem struct {
 	// which variables to apply the integrality constraint to. Should have same order as c.
 	integralityConstraints []bool
 
+	// per-variable lower/upper bounds, jCbc/CLP-style. A nil lb or ub means
+	// every variable defaults to lb=0, ub=+Inf; a ub entry may be +Inf to
+	// leave that one variable's upper bound open. An lb entry of -Inf is not
+	// supported: Convert rejects it, and the relaxation is then reported
+	// infeasible. Kept separate from G/h so a bound doesn't cost a row (and
+	// a simplex pivot) of its own.
+	lb, ub []float64
+
 	// which branching heuristic to use. Determines which integer variable is branched on at each split.
 	// defaults to 0 == maxFun
 	branchingHeuristic BranchHeuristic
+
+	// which cut generators to run during the search, and how to manage the
+	// resulting cut pool. The zero value disables cut generation, reducing
+	// the search to plain branch-and-bound.
+	branchAndCut BranchAndCutOptions
+
+	// optional hook fired at well-defined search events (see Event). Nil
+	// means no callback is invoked.
+	nodeCallback NodeCallback
+
+	// which primal heuristics to run in search of an early incumbent, and
+	// how deep into the tree to keep running them. The zero value disables
+	// heuristics, leaving incumbents to be found by branch-and-bound alone.
+	primalHeuristics HeuristicOptions
+
+	// how many integer-feasible solutions to keep beyond the single
+	// incumbent, and how the tree should spend extra search effort finding
+	// them. The zero value disables pooling.
+	pool PoolOptions
 }
 
 type milpSolution struct {
 	solution solution
+
+	// pool holds every integer-feasible node solution the pool admitted
+	// during the search (see SolutionPool.Add), when PoolOptions enabled
+	// pooling. An incumbent adopted from a primal heuristic or a
+	// NodeCallback is not added to it. Nil when pooling wasn't configured,
+	// and on a solve that ran out of time.
+	pool *SolutionPool
 }
 
 var (
@@ -50,15 +84,21 @@ func (p milpProblem) toInitialSubProblem() subProblem {
 		id: 0,
 
 		// copy (or reference) the initial problem's numerical definition
-		c: p.c,
-		A: p.A,
-		b: p.b,
-		G: p.G,
-		h: p.h,
+		c:                      p.c,
+		A:                      p.A,
+		b:                      p.b,
+		G:                      p.G,
+		h:                      p.h,
 		integralityConstraints: p.integralityConstraints,
+		lb:                     p.lb,
+		ub:                     p.ub,
 
 		// for the initial subproblem, there are no branch-and-bound-specific inequality constraints.
 		bnbConstraints: []bnbConstraint{},
+
+		// no parent to warm start from; basis is left nil so its relaxation
+		// solves cold, the same as before warm starting existed.
+		basis: nil,
 	}
 }
 
@@ -76,8 +116,20 @@ func (p milpProblem) solve(ctx context.Context, workers int, instrumentation bnb
 	// add the initial LP relaxation to the problem queue
 	initialRelaxation := p.toInitialSubProblem()
 
-	// Start the branch and bound procedure for this problem
-	enumTree := newEnumerationTree(initialRelaxation, instrumentation)
+	// When pooling is configured, every integer-feasible node within
+	// PoolOptions.Gap of the incumbent is kept here instead of just being
+	// compared against it and discarded, and a no-good cut is added to push
+	// the search towards alternate optima rather than re-finding the same
+	// point.
+	pool := NewSolutionPool(p.pool)
+
+	// Start the branch and bound procedure for this problem. When cut
+	// generators are configured, the tree also runs them at each eligible
+	// node before branching, appending any resulting rows to that node's G/h.
+	// When primal heuristics are configured, the tree also offers their
+	// candidates as incumbents the same way a NodeCallback's
+	// SetHeuristicSolution would.
+	enumTree := newEnumerationTree(initialRelaxation, instrumentation, p.branchAndCut, p.nodeCallback, p.primalHeuristics, pool)
 
 	// start the branch and bound procedure, presenting the solution to the initial relaxation as a candidate
 	incumbent := enumTree.startSearch(ctx, workers)
@@ -107,8 +159,12 @@ func (p milpProblem) solve(ctx context.Context, workers int, instrumentation bnb
 		return milpSolution{}, NO_INTEGER_FEASIBLE_SOLUTION
 	}
 
-	return milpSolution{
-		solution: *incumbent,
-	}, nil
+	// enumTree adds every pooled node's solution to pool as it goes (see
+	// PoolOptions); only attach it to the result if pooling was actually on.
+	solved := milpSolution{solution: *incumbent}
+	if p.pool.enabled() {
+		solved.pool = pool
+	}
+	return solved, nil
 
 }
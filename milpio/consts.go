@@ -0,0 +1,8 @@
+package milpio
+
+import "math"
+
+var (
+	posInf = math.Inf(1)
+	negInf = math.Inf(-1)
+)
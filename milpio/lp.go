@@ -0,0 +1,358 @@
+package milpio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// lpTermRe matches a single signed term in a linear expression, e.g.
+// "+3.5 x1", "- x2", "x3".
+var lpTermRe = regexp.MustCompile(`([+-]?\s*[0-9]*\.?[0-9]*)\s*([A-Za-z_][A-Za-z0-9_]*)`)
+
+// ReadLP parses a subset of the CPLEX LP format: an objective section
+// (Minimize/Maximize), a "Subject To" section of linear ≤/≥/= rows, and
+// optional Bounds/Binary/General/Integer sections. It does not support
+// quadratic terms, indicator constraints, or SOS sections.
+func ReadLP(r io.Reader) (*Problem, error) {
+	p := &Problem{}
+
+	var (
+		section  string
+		gRows    [][]float64
+		h        []float64
+		aRows    [][]float64
+		b        []float64
+		integers = map[string]bool{}
+		binaries = map[string]bool{}
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripLPComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		switch header := strings.ToLower(line); {
+		case strings.HasPrefix(header, "minimize") || strings.HasPrefix(header, "min"):
+			p.Sense, section = Minimize, "obj"
+			continue
+		case strings.HasPrefix(header, "maximize") || strings.HasPrefix(header, "max"):
+			p.Sense, section = Maximize, "obj"
+			continue
+		case strings.HasPrefix(header, "subject to") || header == "st" || header == "st:" || header == "s.t.":
+			section = "rows"
+			continue
+		case strings.HasPrefix(header, "bounds"):
+			section = "bounds"
+			continue
+		case strings.HasPrefix(header, "binary") || strings.HasPrefix(header, "binaries"):
+			section = "binary"
+			continue
+		case strings.HasPrefix(header, "general") || strings.HasPrefix(header, "integer"):
+			section = "integer"
+			continue
+		case header == "end":
+			section = ""
+			continue
+		}
+
+		switch section {
+		case "obj":
+			terms, _, _, err := parseLinear(line)
+			if err != nil {
+				return nil, err
+			}
+			for name, coef := range terms {
+				p.C[p.colIndex(name)] = coef
+			}
+
+		case "rows":
+			terms, sense, rhs, err := parseLinear(line)
+			if err != nil {
+				return nil, err
+			}
+			coefByCol := make(map[int]float64, len(terms))
+			for name, coef := range terms {
+				coefByCol[p.colIndex(name)] = coef
+			}
+			row := make([]float64, len(p.ColNames))
+			for col, coef := range coefByCol {
+				row[col] = coef
+			}
+			switch sense {
+			case "<=":
+				gRows = append(growAll(gRows, len(p.ColNames)), row)
+				h = append(h, rhs)
+			case ">=":
+				gRows = append(growAll(gRows, len(p.ColNames)), negateLP(row))
+				h = append(h, -rhs)
+			case "=":
+				aRows = append(growAll(aRows, len(p.ColNames)), row)
+				b = append(b, rhs)
+			default:
+				return nil, fmt.Errorf("milpio: unrecognized relational operator in %q", line)
+			}
+
+		case "bounds":
+			if err := parseLPBound(p, line); err != nil {
+				return nil, err
+			}
+
+		case "binary":
+			for _, name := range strings.Fields(line) {
+				binaries[name] = true
+			}
+
+		case "integer":
+			for _, name := range strings.Fields(line) {
+				integers[name] = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	n := len(p.ColNames)
+	for name := range integers {
+		p.Integrality[p.colIndex(name)] = true
+	}
+	for name := range binaries {
+		i := p.colIndex(name)
+		p.Integrality[i] = true
+		p.LB[i], p.UB[i] = 0, 1
+	}
+
+	p.G = rowsToDense(growAll(gRows, n), n)
+	p.H = h
+	p.A = rowsToDense(growAll(aRows, n), n)
+	p.B = b
+	p.C = growRow(p.C, n)
+
+	return p, nil
+}
+
+func stripLPComment(line string) string {
+	if i := strings.Index(line, "\\"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// growRow pads row with zeros up to length n, needed because colIndex can
+// introduce new columns partway through parsing a row.
+func growRow(row []float64, n int) []float64 {
+	for len(row) < n {
+		row = append(row, 0)
+	}
+	return row
+}
+
+func growAll(rows [][]float64, n int) [][]float64 {
+	for i := range rows {
+		rows[i] = growRow(rows[i], n)
+	}
+	return rows
+}
+
+func negateLP(row []float64) []float64 {
+	out := make([]float64, len(row))
+	for i, v := range row {
+		out[i] = -v
+	}
+	return out
+}
+
+// parseLinear splits a "Subject To" or objective line into its linear
+// terms, relational operator (empty for an objective line) and RHS.
+func parseLinear(line string) (terms map[string]float64, sense string, rhs float64, err error) {
+	// drop an optional leading "name:" row label
+	if i := strings.Index(line, ":"); i >= 0 && !strings.ContainsAny(line[:i], "+-<>=. ") {
+		line = line[i+1:]
+	}
+
+	expr := line
+	sense = ""
+	for _, op := range []string{"<=", ">=", "="} {
+		if idx := strings.Index(line, op); idx >= 0 {
+			expr = line[:idx]
+			sense = op
+			rhsStr := strings.TrimSpace(line[idx+len(op):])
+			rhs, err = strconv.ParseFloat(rhsStr, 64)
+			if err != nil {
+				return nil, "", 0, fmt.Errorf("milpio: bad RHS in %q: %w", line, err)
+			}
+			break
+		}
+	}
+
+	terms = map[string]float64{}
+	for _, m := range lpTermRe.FindAllStringSubmatch(expr, -1) {
+		coefStr := strings.ReplaceAll(m[1], " ", "")
+		coef := 1.0
+		switch coefStr {
+		case "", "+":
+			coef = 1
+		case "-":
+			coef = -1
+		default:
+			v, perr := strconv.ParseFloat(coefStr, 64)
+			if perr != nil {
+				return nil, "", 0, fmt.Errorf("milpio: bad coefficient %q in %q", coefStr, line)
+			}
+			coef = v
+		}
+		terms[m[2]] += coef
+	}
+
+	return terms, sense, rhs, nil
+}
+
+// lpDoubleBoundRe matches the double-bounded form WriteLP emits,
+// "lb <= x <= ub", and its mirror "ub >= x >= lb", which CPLEX LP also
+// accepts.
+var lpDoubleBoundRe = regexp.MustCompile(`^(-?[0-9]*\.?[0-9]+)\s*(<=|>=)\s*([A-Za-z_][A-Za-z0-9_]*)\s*(<=|>=)\s*(-?[0-9]*\.?[0-9]+)$`)
+
+// parseLPBound handles one Bounds-section line: "lb <= x <= ub" (or its
+// ">="-chained mirror "ub >= x >= lb"), "x <= ub", "x >= lb", "x = v", or
+// "x free". The double-bounded form isn't a single relational expression,
+// so it's matched directly rather than going through parseLinear, which
+// only ever recognizes one operator per line.
+func parseLPBound(p *Problem, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 2 && strings.EqualFold(fields[1], "free") {
+		i := p.colIndex(fields[0])
+		p.LB[i], p.UB[i] = negInf, posInf
+		return nil
+	}
+
+	if m := lpDoubleBoundRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+		lo, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return fmt.Errorf("milpio: bad bound %q in %q: %w", m[1], line, err)
+		}
+		hi, err := strconv.ParseFloat(m[5], 64)
+		if err != nil {
+			return fmt.Errorf("milpio: bad bound %q in %q: %w", m[5], line, err)
+		}
+		if m[2] != m[4] {
+			return fmt.Errorf("milpio: mismatched relational operators in double-bounded line %q", line)
+		}
+		if m[2] == ">=" {
+			lo, hi = hi, lo
+		}
+		i := p.colIndex(m[3])
+		p.LB[i], p.UB[i] = lo, hi
+		return nil
+	}
+
+	terms, sense, rhs, err := parseLinear(line)
+	if err != nil {
+		return err
+	}
+	if len(terms) != 1 {
+		return fmt.Errorf("milpio: unsupported bound line %q", line)
+	}
+	for name := range terms {
+		i := p.colIndex(name)
+		switch sense {
+		case "<=":
+			p.UB[i] = rhs
+		case ">=":
+			p.LB[i] = rhs
+		case "=":
+			p.LB[i], p.UB[i] = rhs, rhs
+		}
+	}
+	return nil
+}
+
+// WriteLP emits p in CPLEX LP format.
+func WriteLP(w io.Writer, p *Problem) error {
+	bw := bufio.NewWriter(w)
+
+	if p.Sense == Maximize {
+		fmt.Fprintln(bw, "Maximize")
+	} else {
+		fmt.Fprintln(bw, "Minimize")
+	}
+	fmt.Fprintf(bw, " obj: %s\n", formatTerms(p.C, p.ColNames))
+
+	fmt.Fprintln(bw, "Subject To")
+	if p.G != nil {
+		rows, n := p.G.Dims()
+		for i := 0; i < rows; i++ {
+			row := make([]float64, n)
+			for j := 0; j < n; j++ {
+				row[j] = p.G.At(i, j)
+			}
+			fmt.Fprintf(bw, " c%d: %s <= %v\n", i, formatTerms(row, p.ColNames), p.H[i])
+		}
+	}
+	if p.A != nil {
+		rows, n := p.A.Dims()
+		for i := 0; i < rows; i++ {
+			row := make([]float64, n)
+			for j := 0; j < n; j++ {
+				row[j] = p.A.At(i, j)
+			}
+			fmt.Fprintf(bw, " e%d: %s = %v\n", i, formatTerms(row, p.ColNames), p.B[i])
+		}
+	}
+
+	fmt.Fprintln(bw, "Bounds")
+	for j, name := range p.ColNames {
+		lb, ub := boundAt(p.LB, j, 0), boundAt(p.UB, j, posInf)
+		switch {
+		case lb == 0 && ub == posInf:
+			continue
+		case lb == negInf && ub == posInf:
+			fmt.Fprintf(bw, " %s free\n", name)
+		case lb == ub:
+			fmt.Fprintf(bw, " %s = %v\n", name, lb)
+		case ub == posInf:
+			fmt.Fprintf(bw, " %s >= %v\n", name, lb)
+		case lb == negInf:
+			fmt.Fprintf(bw, " %s <= %v\n", name, ub)
+		default:
+			fmt.Fprintf(bw, " %v <= %s <= %v\n", lb, name, ub)
+		}
+	}
+
+	var integers []string
+	for j, name := range p.ColNames {
+		if j < len(p.Integrality) && p.Integrality[j] {
+			integers = append(integers, name)
+		}
+	}
+	if len(integers) > 0 {
+		fmt.Fprintln(bw, "General")
+		fmt.Fprintln(bw, " "+strings.Join(integers, " "))
+	}
+
+	fmt.Fprintln(bw, "End")
+	return bw.Flush()
+}
+
+func formatTerms(coefs []float64, names []string) string {
+	var parts []string
+	for j, c := range coefs {
+		if c == 0 {
+			continue
+		}
+		sign := "+"
+		if c < 0 {
+			sign, c = "-", -c
+		}
+		parts = append(parts, fmt.Sprintf("%s %v %s", sign, c, names[j]))
+	}
+	if len(parts) == 0 {
+		return "0"
+	}
+	return strings.Join(parts, " ")
+}
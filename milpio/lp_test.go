@@ -0,0 +1,105 @@
+package milpio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadLP_DoubleBoundedVariable(t *testing.T) {
+	src := `Minimize
+ obj: x1
+Subject To
+ c0: x1 + x2 <= 10
+Bounds
+ 2 <= x1 <= 8
+ 8 >= x2 >= 1
+End
+`
+	p, err := ReadLP(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadLP: %v", err)
+	}
+
+	i1, i2 := p.colIndex("x1"), p.colIndex("x2")
+	if p.LB[i1] != 2 || p.UB[i1] != 8 {
+		t.Fatalf("x1 bounds = [%v, %v], want [2, 8]", p.LB[i1], p.UB[i1])
+	}
+	if p.LB[i2] != 1 || p.UB[i2] != 8 {
+		t.Fatalf("x2 bounds = [%v, %v], want [1, 8]", p.LB[i2], p.UB[i2])
+	}
+}
+
+func TestReadLP_WriteLPRoundTripsDoubleBounds(t *testing.T) {
+	p := &Problem{Sense: Minimize}
+	i := p.colIndex("x")
+	p.C[i] = 1
+	p.LB[i], p.UB[i] = 3, 7
+
+	var buf bytes.Buffer
+	if err := WriteLP(&buf, p); err != nil {
+		t.Fatalf("WriteLP: %v", err)
+	}
+
+	got, err := ReadLP(&buf)
+	if err != nil {
+		t.Fatalf("ReadLP of WriteLP output: %v\n%s", err, buf.String())
+	}
+	j := got.colIndex("x")
+	if got.LB[j] != 3 || got.UB[j] != 7 {
+		t.Fatalf("round-tripped bounds = [%v, %v], want [3, 7]", got.LB[j], got.UB[j])
+	}
+}
+
+func TestReadLP_SingleSidedBoundsAndFree(t *testing.T) {
+	src := `Minimize
+ obj: x1 + x2 + x3
+Subject To
+ c0: x1 + x2 + x3 <= 10
+Bounds
+ x1 <= 5
+ x2 >= -3
+ x3 free
+End
+`
+	p, err := ReadLP(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadLP: %v", err)
+	}
+	i1, i2, i3 := p.colIndex("x1"), p.colIndex("x2"), p.colIndex("x3")
+	if p.UB[i1] != 5 {
+		t.Fatalf("x1 UB = %v, want 5", p.UB[i1])
+	}
+	if p.LB[i2] != -3 {
+		t.Fatalf("x2 LB = %v, want -3", p.LB[i2])
+	}
+	if p.LB[i3] != negInf || p.UB[i3] != posInf {
+		t.Fatalf("x3 bounds = [%v, %v], want [-Inf, +Inf]", p.LB[i3], p.UB[i3])
+	}
+}
+
+// TestWriteLPLowerBoundOnlyRoundTrip covers the bound shape
+// TestReadLP_WriteLPRoundTripsDoubleBounds doesn't: a variable with only a
+// finite lower bound (ub left at +Inf). WriteLP used to emit the literal
+// endpoint "5 <= x <= +Inf", which ReadLP's double-bound regex can't
+// parse back.
+func TestWriteLPLowerBoundOnlyRoundTrip(t *testing.T) {
+	p := &Problem{Sense: Minimize}
+	i := p.colIndex("x")
+	p.C[i] = 1
+	p.LB[i] = 5 // only a lower bound; ub stays +Inf
+
+	var buf bytes.Buffer
+	if err := WriteLP(&buf, p); err != nil {
+		t.Fatalf("WriteLP: %v", err)
+	}
+
+	got, err := ReadLP(&buf)
+	if err != nil {
+		t.Fatalf("ReadLP of WriteLP output: %v\n%s", err, buf.String())
+	}
+	j := got.colIndex("x")
+	if got.LB[j] != 5 || got.UB[j] != posInf {
+		t.Fatalf("round-tripped bounds = [%v, %v], want [5, +Inf]", got.LB[j], got.UB[j])
+	}
+}
@@ -0,0 +1,65 @@
+// Package milpio reads and writes MILP instances in the standard MPS and
+// CPLEX LP file formats, so a milpProblem can be described textually
+// instead of assembled by hand out of mat.Dense, and so GoMILP can be
+// benchmarked against Cbc/Clp/GLPK on the same MIPLIB/COIN-OR test files.
+package milpio
+
+import "gonum.org/v1/gonum/mat"
+
+// ObjSense is the direction of optimization. Both formats default to
+// Minimize when no sense is stated explicitly.
+type ObjSense int
+
+const (
+	Minimize ObjSense = iota
+	Maximize
+)
+
+// Problem is milpio's file-format-agnostic representation of a MILP. It has
+// the same numerical shape as ilp's internal milpProblem (minimize C^T x
+// s.t. A x = B, G x <= H, with per-variable LB/UB and Integrality flags),
+// exported so a reader can build one without needing access to ilp's
+// unexported fields; callers hand it to the solver's own constructor.
+type Problem struct {
+	Name  string
+	Sense ObjSense
+
+	C []float64
+	A *mat.Dense
+	B []float64
+	G *mat.Dense
+	H []float64
+
+	Integrality []bool
+	LB, UB      []float64
+
+	// ColNames preserves column order and names from the source file, since
+	// both MPS and LP reference variables by name rather than index.
+	ColNames []string
+}
+
+// colIndex returns the index of name in p.ColNames, adding it (and growing
+// every parallel per-variable slice) if it hasn't been seen yet.
+func (p *Problem) colIndex(name string) int {
+	for i, n := range p.ColNames {
+		if n == name {
+			return i
+		}
+	}
+
+	i := len(p.ColNames)
+	p.ColNames = append(p.ColNames, name)
+	p.C = append(p.C, 0)
+	p.Integrality = append(p.Integrality, false)
+	p.LB = append(p.LB, 0)
+	p.UB = append(p.UB, posInf)
+	return i
+}
+
+// boundAt returns bounds[i], or def if bounds is nil/too short.
+func boundAt(bounds []float64, i int, def float64) float64 {
+	if i >= len(bounds) {
+		return def
+	}
+	return bounds[i]
+}
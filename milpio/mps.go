@@ -0,0 +1,423 @@
+package milpio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// rowKind is an MPS ROWS section row type.
+type rowKind byte
+
+const (
+	rowObjective rowKind = 'N'
+	rowLE        rowKind = 'L'
+	rowGE        rowKind = 'G'
+	rowEQ        rowKind = 'E'
+)
+
+// mpsRow is a single named constraint row being assembled while reading.
+type mpsRow struct {
+	kind   rowKind
+	coefs  map[int]float64 // column index -> coefficient
+	rhs    float64
+	rng    float64
+	hasRng bool
+}
+
+// ReadMPS parses an MPS file (fixed or free format; this reader treats both
+// the same way, splitting on whitespace, which is compatible with virtually
+// every MPS file encountered in practice). It supports OBJSENSE, ROWS,
+// COLUMNS (including MARKER 'INTORG'/'INTEND' integrality markers), RHS,
+// RANGES and BOUNDS (UP, LO, FX, FR, MI, PL, BV, LI, UI).
+func ReadMPS(r io.Reader) (*Problem, error) {
+	p := &Problem{}
+
+	var (
+		rowByName = map[string]int{}
+		rows      []mpsRow
+		objRow    = -1
+		inInteger bool
+		section   string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "*") {
+			continue // full-line comment
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// A section header starts in column 1 (no leading whitespace).
+		if len(line) > 0 && line[0] != ' ' && line[0] != '\t' {
+			fields := strings.Fields(line)
+			section = strings.ToUpper(fields[0])
+			switch section {
+			case "NAME":
+				if len(fields) > 1 {
+					p.Name = fields[1]
+				}
+			case "ENDATA":
+				section = ""
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch section {
+		case "OBJSENSE":
+			switch strings.ToUpper(fields[0]) {
+			case "MAX", "MAXIMIZE":
+				p.Sense = Maximize
+			default:
+				p.Sense = Minimize
+			}
+
+		case "ROWS":
+			kind := rowKind(strings.ToUpper(fields[0])[0])
+			name := fields[1]
+			rowByName[name] = len(rows)
+			rows = append(rows, mpsRow{kind: kind, coefs: map[int]float64{}})
+			if kind == rowObjective && objRow == -1 {
+				objRow = rowByName[name]
+			}
+
+		case "COLUMNS":
+			if len(fields) >= 3 && strings.Contains(strings.ToUpper(fields[0]), "MARKER") {
+				switch strings.ToUpper(fields[2]) {
+				case "'INTORG'":
+					inInteger = true
+				case "'INTEND'":
+					inInteger = false
+				}
+				continue
+			}
+
+			col := p.colIndex(fields[0])
+			p.Integrality[col] = p.Integrality[col] || inInteger
+			if err := setPairs(fields[1:], func(rowName string, val float64) error {
+				ri, ok := rowByName[rowName]
+				if !ok {
+					return fmt.Errorf("milpio: COLUMNS references unknown row %q", rowName)
+				}
+				rows[ri].coefs[col] = val
+				if ri == objRow {
+					p.C[col] = val
+				}
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+
+		case "RHS":
+			// the first field is a conventional (and ignorable) RHS vector
+			// name and is always skipped; the remaining fields must be
+			// row/value pairs.
+			vals := fields[1:]
+			if len(vals)%2 != 0 {
+				return nil, fmt.Errorf("milpio: malformed RHS line %q", line)
+			}
+			if err := setPairs(vals, func(rowName string, val float64) error {
+				ri, ok := rowByName[rowName]
+				if !ok {
+					return fmt.Errorf("milpio: RHS references unknown row %q", rowName)
+				}
+				rows[ri].rhs = val
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+
+		case "RANGES":
+			vals := fields[1:]
+			if err := setPairs(vals, func(rowName string, val float64) error {
+				ri, ok := rowByName[rowName]
+				if !ok {
+					return fmt.Errorf("milpio: RANGES references unknown row %q", rowName)
+				}
+				rows[ri].rng, rows[ri].hasRng = val, true
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+
+		case "BOUNDS":
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("milpio: malformed BOUNDS line %q", line)
+			}
+			kind := strings.ToUpper(fields[0])
+			col := p.colIndex(fields[2])
+			var val float64
+			if len(fields) > 3 {
+				v, err := strconv.ParseFloat(fields[3], 64)
+				if err != nil {
+					return nil, fmt.Errorf("milpio: bad bound value %q: %w", fields[3], err)
+				}
+				val = v
+			}
+			applyBound(p, kind, col, val)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if objRow == -1 {
+		return nil, fmt.Errorf("milpio: no objective (N) row found")
+	}
+
+	n := len(p.ColNames)
+	var gRows, aRows [][]float64
+	var h, b []float64
+	for i, row := range rows {
+		if i == objRow {
+			continue
+		}
+		isRange := row.hasRng
+		switch row.kind {
+		case rowEQ:
+			if isRange {
+				// E row with a range splits into two <= rows per the MPS
+				// spec; sign of the range picks which side the slack is on.
+				lower, upper := row.rhs, row.rhs+row.rng
+				if row.rng < 0 {
+					lower, upper = row.rhs+row.rng, row.rhs
+				}
+				gRows = append(gRows, denseCoefs(row.coefs, n))
+				h = append(h, upper)
+				gRows = append(gRows, negate(denseCoefs(row.coefs, n)))
+				h = append(h, -lower)
+				continue
+			}
+			aRows = append(aRows, denseCoefs(row.coefs, n))
+			b = append(b, row.rhs)
+
+		case rowLE:
+			gRows = append(gRows, denseCoefs(row.coefs, n))
+			upper := row.rhs
+			if isRange {
+				h = append(h, upper)
+				lower := upper - abs(row.rng)
+				gRows = append(gRows, negate(denseCoefs(row.coefs, n)))
+				h = append(h, -lower)
+				continue
+			}
+			h = append(h, upper)
+
+		case rowGE:
+			// a·x >= rhs  <=>  -a·x <= -rhs
+			gRows = append(gRows, negate(denseCoefs(row.coefs, n)))
+			lower := row.rhs
+			if isRange {
+				h = append(h, -lower)
+				upper := lower + abs(row.rng)
+				gRows = append(gRows, denseCoefs(row.coefs, n))
+				h = append(h, upper)
+				continue
+			}
+			h = append(h, -lower)
+		}
+	}
+
+	p.G = rowsToDense(gRows, n)
+	p.H = h
+	p.A = rowsToDense(aRows, n)
+	p.B = b
+
+	return p, nil
+}
+
+// setPairs walks fields two at a time (name, value) and calls set for each,
+// which both COLUMNS/RHS/RANGES lines use since MPS allows packing two
+// row/value pairs per line.
+func setPairs(fields []string, set func(name string, val float64) error) error {
+	if len(fields)%2 != 0 {
+		return fmt.Errorf("milpio: expected name/value pairs, got %v", fields)
+	}
+	for i := 0; i < len(fields); i += 2 {
+		val, err := strconv.ParseFloat(fields[i+1], 64)
+		if err != nil {
+			return fmt.Errorf("milpio: bad value %q: %w", fields[i+1], err)
+		}
+		if err := set(fields[i], val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyBound(p *Problem, kind string, col int, val float64) {
+	switch kind {
+	case "UP":
+		p.UB[col] = val
+	case "LO":
+		p.LB[col] = val
+	case "FX":
+		p.LB[col], p.UB[col] = val, val
+	case "FR":
+		p.LB[col], p.UB[col] = negInf, posInf
+	case "MI":
+		p.LB[col] = negInf
+	case "PL":
+		p.UB[col] = posInf
+	case "BV":
+		p.LB[col], p.UB[col] = 0, 1
+		p.Integrality[col] = true
+	case "LI":
+		p.LB[col] = val
+		p.Integrality[col] = true
+	case "UI":
+		p.UB[col] = val
+		p.Integrality[col] = true
+	}
+}
+
+func denseCoefs(coefs map[int]float64, n int) []float64 {
+	row := make([]float64, n)
+	for j, v := range coefs {
+		row[j] = v
+	}
+	return row
+}
+
+func negate(row []float64) []float64 {
+	out := make([]float64, len(row))
+	for i, v := range row {
+		out[i] = -v
+	}
+	return out
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func rowsToDense(rows [][]float64, n int) *mat.Dense {
+	if len(rows) == 0 {
+		return nil
+	}
+	d := mat.NewDense(len(rows), n, nil)
+	for i, row := range rows {
+		d.SetRow(i, row)
+	}
+	return d
+}
+
+// WriteMPS emits p in free MPS format.
+func WriteMPS(w io.Writer, p *Problem) error {
+	bw := bufio.NewWriter(w)
+
+	name := p.Name
+	if name == "" {
+		name = "GOMILP"
+	}
+	fmt.Fprintf(bw, "NAME %s\n", name)
+	if p.Sense == Maximize {
+		fmt.Fprintln(bw, "OBJSENSE")
+		fmt.Fprintln(bw, " MAX")
+	}
+
+	fmt.Fprintln(bw, "ROWS")
+	fmt.Fprintln(bw, " N  COST")
+	gRows, _ := 0, 0
+	if p.G != nil {
+		gRows, _ = p.G.Dims()
+	}
+	for i := 0; i < gRows; i++ {
+		fmt.Fprintf(bw, " L  LE%d\n", i)
+	}
+	aRows := 0
+	if p.A != nil {
+		aRows, _ = p.A.Dims()
+	}
+	for i := 0; i < aRows; i++ {
+		fmt.Fprintf(bw, " E  EQ%d\n", i)
+	}
+
+	fmt.Fprintln(bw, "COLUMNS")
+	inInteger := false
+	markerID := 0
+	for j, name := range p.ColNames {
+		integer := j < len(p.Integrality) && p.Integrality[j]
+		if integer && !inInteger {
+			fmt.Fprintf(bw, "    MARKER                 %-10s'INTORG'\n", fmt.Sprintf("M%d", markerID))
+			markerID++
+			inInteger = true
+		} else if !integer && inInteger {
+			fmt.Fprintf(bw, "    MARKER                 %-10s'INTEND'\n", fmt.Sprintf("M%d", markerID))
+			markerID++
+			inInteger = false
+		}
+
+		if p.C[j] != 0 {
+			fmt.Fprintf(bw, "    %-10s%-10s%v\n", name, "COST", p.C[j])
+		}
+		for i := 0; i < gRows; i++ {
+			if v := p.G.At(i, j); v != 0 {
+				fmt.Fprintf(bw, "    %-10s%-10s%v\n", name, fmt.Sprintf("LE%d", i), v)
+			}
+		}
+		for i := 0; i < aRows; i++ {
+			if v := p.A.At(i, j); v != 0 {
+				fmt.Fprintf(bw, "    %-10s%-10s%v\n", name, fmt.Sprintf("EQ%d", i), v)
+			}
+		}
+	}
+	if inInteger {
+		fmt.Fprintf(bw, "    MARKER                 %-10s'INTEND'\n", fmt.Sprintf("M%d", markerID))
+	}
+
+	fmt.Fprintln(bw, "RHS")
+	for i := 0; i < gRows; i++ {
+		fmt.Fprintf(bw, "    RHS       %-10s%v\n", fmt.Sprintf("LE%d", i), p.H[i])
+	}
+	for i := 0; i < aRows; i++ {
+		fmt.Fprintf(bw, "    RHS       %-10s%v\n", fmt.Sprintf("EQ%d", i), p.B[i])
+	}
+
+	fmt.Fprintln(bw, "BOUNDS")
+	for j, name := range p.ColNames {
+		lb, ub := 0.0, posInf
+		if j < len(p.LB) {
+			lb = p.LB[j]
+		}
+		if j < len(p.UB) {
+			ub = p.UB[j]
+		}
+		switch {
+		case lb == 0 && ub == posInf:
+			// default bounds; nothing to emit
+		case lb == ub:
+			fmt.Fprintf(bw, " FX BND       %-10s%v\n", name, lb)
+		case lb == negInf && ub == posInf:
+			fmt.Fprintf(bw, " FR BND       %-10s\n", name)
+		default:
+			if lb != 0 {
+				if lb == negInf {
+					fmt.Fprintf(bw, " MI BND       %-10s\n", name)
+				} else {
+					fmt.Fprintf(bw, " LO BND       %-10s%v\n", name, lb)
+				}
+			}
+			if ub != posInf {
+				fmt.Fprintf(bw, " UP BND       %-10s%v\n", name, ub)
+			}
+		}
+	}
+
+	fmt.Fprintln(bw, "ENDATA")
+	return bw.Flush()
+}
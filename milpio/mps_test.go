@@ -0,0 +1,213 @@
+package milpio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadMPS_BasicProblem(t *testing.T) {
+	src := `NAME          TEST
+ROWS
+ N  COST
+ L  LIM1
+ G  LIM2
+ E  EQ1
+COLUMNS
+    x1        COST      1.0        LIM1      1.0
+    x1        LIM2      1.0        EQ1       1.0
+    x2        COST      2.0        LIM1      1.0
+RHS
+    RHS       LIM1      10.0       LIM2      1.0
+    RHS       EQ1       4.0
+BOUNDS
+ENDATA
+`
+	p, err := ReadMPS(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadMPS: %v", err)
+	}
+	if p.Name != "TEST" {
+		t.Fatalf("Name = %q, want TEST", p.Name)
+	}
+
+	i1, i2 := p.colIndex("x1"), p.colIndex("x2")
+	if p.C[i1] != 1 || p.C[i2] != 2 {
+		t.Fatalf("C = %v, want [1, 2]", p.C)
+	}
+
+	gRows, _ := p.G.Dims()
+	if gRows != 2 {
+		t.Fatalf("expected 2 G rows (LIM1, negated LIM2), got %d", gRows)
+	}
+	if p.G.At(0, i1) != 1 || p.H[0] != 10 {
+		t.Fatalf("LIM1 row = %v <= %v, want [1 0] <= 10", p.G.RawRowView(0), p.H[0])
+	}
+	if p.G.At(1, i1) != -1 || p.H[1] != -1 {
+		t.Fatalf("negated LIM2 row = %v <= %v, want [-1 0] <= -1", p.G.RawRowView(1), p.H[1])
+	}
+
+	aRows, _ := p.A.Dims()
+	if aRows != 1 || p.A.At(0, i1) != 1 || p.B[0] != 4 {
+		t.Fatalf("EQ1 row = %v = %v, want [1 0] = 4", p.A.RawRowView(0), p.B[0])
+	}
+}
+
+func TestReadMPS_ObjsenseMax(t *testing.T) {
+	src := `NAME
+OBJSENSE
+ MAX
+ROWS
+ N  COST
+COLUMNS
+    x1        COST      1.0
+RHS
+ENDATA
+`
+	p, err := ReadMPS(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadMPS: %v", err)
+	}
+	if p.Sense != Maximize {
+		t.Fatalf("Sense = %v, want Maximize", p.Sense)
+	}
+}
+
+func TestReadMPS_IntegerMarkers(t *testing.T) {
+	src := `NAME
+ROWS
+ N  COST
+COLUMNS
+    MARKER                 M1        'INTORG'
+    x1        COST      1.0
+    MARKER                 M1        'INTEND'
+    x2        COST      1.0
+RHS
+ENDATA
+`
+	p, err := ReadMPS(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadMPS: %v", err)
+	}
+	i1, i2 := p.colIndex("x1"), p.colIndex("x2")
+	if !p.Integrality[i1] {
+		t.Fatalf("x1 should be integer (inside INTORG/INTEND)")
+	}
+	if p.Integrality[i2] {
+		t.Fatalf("x2 should not be integer (outside INTORG/INTEND)")
+	}
+}
+
+func TestReadMPS_Bounds(t *testing.T) {
+	src := `NAME
+ROWS
+ N  COST
+COLUMNS
+    x1        COST      1.0
+    x2        COST      1.0
+    x3        COST      1.0
+    x4        COST      1.0
+    x5        COST      1.0
+RHS
+BOUNDS
+ UP BND       x1        5.0
+ LO BND       x2        -3.0
+ FX BND       x3        2.0
+ FR BND       x4
+ BV BND       x5
+ENDATA
+`
+	p, err := ReadMPS(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadMPS: %v", err)
+	}
+	i1, i2, i3, i4, i5 := p.colIndex("x1"), p.colIndex("x2"), p.colIndex("x3"), p.colIndex("x4"), p.colIndex("x5")
+
+	if p.UB[i1] != 5 {
+		t.Fatalf("x1 UB = %v, want 5", p.UB[i1])
+	}
+	if p.LB[i2] != -3 {
+		t.Fatalf("x2 LB = %v, want -3", p.LB[i2])
+	}
+	if p.LB[i3] != 2 || p.UB[i3] != 2 {
+		t.Fatalf("x3 bounds = [%v, %v], want [2, 2]", p.LB[i3], p.UB[i3])
+	}
+	if p.LB[i4] != negInf || p.UB[i4] != posInf {
+		t.Fatalf("x4 bounds = [%v, %v], want [-Inf, +Inf]", p.LB[i4], p.UB[i4])
+	}
+	if p.LB[i5] != 0 || p.UB[i5] != 1 || !p.Integrality[i5] {
+		t.Fatalf("x5 should be a binary [0,1] integer, got bounds=[%v,%v] integer=%v", p.LB[i5], p.UB[i5], p.Integrality[i5])
+	}
+}
+
+func TestReadMPS_RangesOnLERow(t *testing.T) {
+	// L row with RHS 10 and range 4 means 6 <= row <= 10.
+	src := `NAME
+ROWS
+ N  COST
+ L  LIM1
+COLUMNS
+    x1        COST      1.0        LIM1      1.0
+RHS
+    RHS       LIM1      10.0
+RANGES
+    RNG       LIM1      4.0
+ENDATA
+`
+	p, err := ReadMPS(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReadMPS: %v", err)
+	}
+	gRows, _ := p.G.Dims()
+	if gRows != 2 {
+		t.Fatalf("expected 2 rows from a ranged L row, got %d", gRows)
+	}
+	if p.H[0] != 10 || p.H[1] != -6 {
+		t.Fatalf("H = %v, want [10, -6] (x<=10 and -x<=-6)", p.H)
+	}
+}
+
+func TestReadMPS_UnknownRowErrors(t *testing.T) {
+	src := `NAME
+ROWS
+ N  COST
+COLUMNS
+    x1        COST      1.0        NOSUCH    1.0
+RHS
+ENDATA
+`
+	if _, err := ReadMPS(strings.NewReader(src)); err == nil {
+		t.Fatalf("expected an error referencing an unknown row")
+	}
+}
+
+func TestWriteMPS_RoundTripsIntegerAndBounds(t *testing.T) {
+	p := &Problem{Sense: Maximize}
+	i := p.colIndex("x1")
+	p.C[i] = 3
+	p.Integrality[i] = true
+	p.LB[i], p.UB[i] = 0, 4
+
+	var buf bytes.Buffer
+	if err := WriteMPS(&buf, p); err != nil {
+		t.Fatalf("WriteMPS: %v", err)
+	}
+
+	got, err := ReadMPS(&buf)
+	if err != nil {
+		t.Fatalf("ReadMPS of WriteMPS output: %v\n%s", err, buf.String())
+	}
+	if got.Sense != Maximize {
+		t.Fatalf("round-tripped Sense = %v, want Maximize", got.Sense)
+	}
+	j := got.colIndex("x1")
+	if got.C[j] != 3 {
+		t.Fatalf("round-tripped C = %v, want 3", got.C[j])
+	}
+	if !got.Integrality[j] {
+		t.Fatalf("round-tripped x1 should still be integer")
+	}
+	if got.UB[j] != 4 {
+		t.Fatalf("round-tripped UB = %v, want 4", got.UB[j])
+	}
+}
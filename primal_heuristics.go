@@ -0,0 +1,45 @@
+package ilp
+
+import "github.com/rlacjfjin/GoMILP/heuristics"
+
+// HeuristicOptions configures which primal heuristics run during the
+// search, looking for an early incumbent so the tree can start pruning
+// before branch-and-bound proves a solution on its own.
+type HeuristicOptions struct {
+	// Heuristics are tried, in order, at every eligible node, stopping at
+	// the first one that proposes a feasible candidate.
+	Heuristics []heuristics.Heuristic
+
+	// MaxDepth limits heuristic runs to nodes at depth <= MaxDepth from the
+	// root. A negative value means unlimited depth; the zero value (the
+	// default for an unset HeuristicOptions) restricts runs to the root
+	// node only.
+	MaxDepth int
+
+	// Frequency runs heuristics only every Frequency-th eligible node
+	// instead of every one, trading incumbent freshness for the LP
+	// re-solves heuristics like FeasibilityPump cost. Zero and one both
+	// mean every eligible node.
+	Frequency int
+
+	// NodeBudget is the sub-MIP node budget handed to a heuristic whose
+	// Context.SolveSubMIP is wired in (see heuristics.RINS) when the
+	// heuristic doesn't set its own budget. Zero, with no budget from the
+	// heuristic either, runs the sub-MIP to completion uncapped.
+	NodeBudget int
+}
+
+// enabled reports whether any primal heuristic should run at all, so the
+// common case (none configured) can skip building a heuristics.Context.
+func (o HeuristicOptions) enabled() bool {
+	return len(o.Heuristics) > 0
+}
+
+// eligible reports whether a node at depth should be considered for
+// heuristic runs at all, per MaxDepth's depth<=MaxDepth rule (a negative
+// MaxDepth means unlimited). Frequency is applied on top of this by the
+// enumeration tree, which is the only place that knows how many eligible
+// nodes have gone by.
+func (o HeuristicOptions) eligible(depth int) bool {
+	return o.MaxDepth < 0 || depth <= o.MaxDepth
+}
@@ -0,0 +1,193 @@
+package ilp
+
+import (
+	"container/heap"
+	"math"
+)
+
+// PoolSearchMode names how the enumeration tree spends the extra search
+// effort needed to fill a SolutionPool once an incumbent has already been
+// found. The enumeration tree does not read it: every mode explores nodes
+// in the tree's own depth-first order.
+type PoolSearchMode int
+
+const (
+	// PoolBestFirst names exploring nodes in the same order used to find
+	// the incumbent.
+	PoolBestFirst PoolSearchMode = iota
+
+	// PoolDiverse names deprioritizing nodes whose relaxation is close to a
+	// solution already in the pool (see SolutionPool.NearExisting); the
+	// enumeration tree does not apply this ordering.
+	PoolDiverse
+)
+
+// PoolOptions configures k-best/diverse solution enumeration. The zero
+// value disables pooling, so solve keeps only the single incumbent, the
+// same as before pooling existed.
+type PoolOptions struct {
+	// Size is the maximum number of solutions kept in the pool. Zero
+	// disables pooling.
+	Size int
+
+	// Gap is how far, relative to the best objective found so far, an
+	// integer-feasible point's objective may be and still be worth adding
+	// to the pool instead of being discarded as soon as it's beaten. Zero
+	// only pools solutions that tie the best found so far.
+	Gap float64
+
+	// SearchMode is kept with the options but not read by the enumeration
+	// tree, which explores nodes depth-first whatever its value.
+	// SolutionPool.NearExisting provides the closeness check PoolDiverse
+	// refers to; nothing in the search calls it.
+	SearchMode PoolSearchMode
+}
+
+// enabled reports whether pooling should happen at all, so the common case
+// (no pool configured) can skip maintaining one.
+func (o PoolOptions) enabled() bool {
+	return o.Size > 0
+}
+
+// withinGap reports whether z is close enough to best (the incumbent's
+// objective) to be worth keeping in the pool rather than discarded outright.
+func (o PoolOptions) withinGap(z, best float64) bool {
+	return math.Abs(z-best) <= o.Gap*math.Max(1, math.Abs(best))
+}
+
+// SolutionPool is a bounded collection of integer-feasible solutions, kept
+// as a max-heap on objective value so the worst member - the first one to
+// evict when a better candidate arrives and the pool is full - is always at
+// the root.
+type SolutionPool struct {
+	opts PoolOptions
+	sols []solution
+
+	// best and hasBest track the best objective ever offered to Add, so
+	// opts.Gap can be measured against the true incumbent rather than
+	// whatever happens to be the pool's current worst member.
+	best    float64
+	hasBest bool
+}
+
+// NewSolutionPool returns an empty pool configured by opts.
+func NewSolutionPool(opts PoolOptions) *SolutionPool {
+	return &SolutionPool{opts: opts}
+}
+
+// Add offers sol to the pool, keeping it if the pool has room or if sol
+// beats the pool's current worst member, and reports whether it was kept.
+// Once an incumbent exists, a candidate outside opts.Gap of it is rejected
+// outright regardless of room, so a full Gap sweep of the tree doesn't
+// drown the pool in solutions nobody asked to see.
+func (p *SolutionPool) Add(sol solution) bool {
+	if !p.opts.enabled() {
+		return false
+	}
+	if !p.hasBest || sol.z < p.best {
+		p.best = sol.z
+		p.hasBest = true
+	}
+	if p.hasBest && !p.opts.withinGap(sol.z, p.best) {
+		return false
+	}
+	if len(p.sols) < p.opts.Size {
+		heap.Push((*poolHeap)(p), sol)
+		return true
+	}
+	if worst := p.sols[0]; sol.z >= worst.z {
+		return false
+	}
+	heap.Pop((*poolHeap)(p))
+	heap.Push((*poolHeap)(p), sol)
+	return true
+}
+
+// NearExisting reports whether z is within opts.Gap of some solution
+// already pooled. It is the closeness test PoolDiverse refers to; the
+// enumeration tree does not call it, so it has no effect on node order.
+func (p *SolutionPool) NearExisting(z float64) bool {
+	for _, sol := range p.sols {
+		if p.opts.withinGap(z, sol.z) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len reports how many solutions are currently pooled.
+func (p *SolutionPool) Len() int {
+	return len(p.sols)
+}
+
+// Solutions returns every pooled solution within opts.Gap of the best
+// objective ever offered to Add, best objective first. A solution admitted
+// while it still tied or neared an earlier, worse incumbent can fall
+// outside opts.Gap once a later call improves p.best; filtering here
+// rather than evicting eagerly in Add keeps Len/NearExisting reflecting
+// every admitted candidate while still honoring the documented Gap
+// invariant for whatever the search ultimately reports.
+func (p *SolutionPool) Solutions() []solution {
+	out := make([]solution, 0, len(p.sols))
+	for _, sol := range p.sols {
+		if !p.hasBest || p.opts.withinGap(sol.z, p.best) {
+			out = append(out, sol)
+		}
+	}
+	sortByZ(out)
+	return out
+}
+
+// sortByZ insertion-sorts sols by objective ascending; pools are small
+// (bounded by PoolOptions.Size), so this never needs to be sort.Slice.
+func sortByZ(sols []solution) {
+	for i := 1; i < len(sols); i++ {
+		for j := i; j > 0 && sols[j].z < sols[j-1].z; j-- {
+			sols[j], sols[j-1] = sols[j-1], sols[j]
+		}
+	}
+}
+
+// poolHeap adapts SolutionPool.sols to container/heap, ordered so the
+// worst (highest-z) solution is always at index 0.
+type poolHeap SolutionPool
+
+func (h poolHeap) Len() int            { return len(h.sols) }
+func (h poolHeap) Less(i, j int) bool  { return h.sols[i].z > h.sols[j].z }
+func (h poolHeap) Swap(i, j int)       { h.sols[i], h.sols[j] = h.sols[j], h.sols[i] }
+func (h *poolHeap) Push(x interface{}) { h.sols = append(h.sols, x.(solution)) }
+func (h *poolHeap) Pop() interface{} {
+	old := h.sols
+	n := len(old)
+	v := old[n-1]
+	h.sols = old[:n-1]
+	return v
+}
+
+// noGoodCut returns a G-row (coeffs, rhs), in the problem's G*x <= h sense,
+// that x itself violates but every other 0/1 point satisfies:
+//
+//	sum_{i: x_i=1} (1 - x_i) + sum_{i: x_i=0} x_i >= 1
+//
+// rearranged into <= form. Appending it to a node's G/h before re-exploring
+// forces the search away from x towards alternate optima. Only
+// binary-flagged variables take part in the cut's support; continuous and
+// general-integer variables are left at coefficient 0, since the
+// (1 - x_i)/x_i derivation only makes sense for a variable with exactly two
+// feasible states.
+func noGoodCut(x []float64, binary []bool) (coeffs []float64, rhs float64) {
+	coeffs = make([]float64, len(x))
+	var ones float64
+	for i, isBinary := range binary {
+		if !isBinary {
+			continue
+		}
+		if math.Round(x[i]) == 1 {
+			coeffs[i] = 1
+			ones++
+		} else {
+			coeffs[i] = -1
+		}
+	}
+	return coeffs, ones - 1
+}
@@ -0,0 +1,117 @@
+package ilp
+
+import "testing"
+
+func TestSolutionPool_Disabled(t *testing.T) {
+	p := NewSolutionPool(PoolOptions{})
+	if p.Add(solution{z: 1}) {
+		t.Fatalf("expected a zero-Size pool to reject every candidate")
+	}
+	if p.Len() != 0 {
+		t.Fatalf("Len = %d, want 0", p.Len())
+	}
+}
+
+func TestSolutionPool_FillsUpToSize(t *testing.T) {
+	p := NewSolutionPool(PoolOptions{Size: 2})
+	if !p.Add(solution{z: 3}) {
+		t.Fatalf("expected first candidate to be kept")
+	}
+	if !p.Add(solution{z: 1}) {
+		t.Fatalf("expected second candidate to be kept while the pool has room")
+	}
+	if p.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", p.Len())
+	}
+}
+
+func TestSolutionPool_EvictsWorstWhenFull(t *testing.T) {
+	// Gap wide enough that only Size and the worst member decide admission.
+	p := NewSolutionPool(PoolOptions{Size: 2, Gap: 10})
+	p.Add(solution{z: 3})
+	p.Add(solution{z: 1})
+
+	if p.Add(solution{z: 5}) {
+		t.Fatalf("a candidate worse than the pool's current worst member must be rejected")
+	}
+	if !p.Add(solution{z: 0}) {
+		t.Fatalf("a candidate better than the pool's current worst member must be kept")
+	}
+
+	got := p.Solutions()
+	want := []float64{0, 1}
+	for i, sol := range got {
+		if sol.z != want[i] {
+			t.Fatalf("Solutions()[%d].z = %v, want %v", i, sol.z, want[i])
+		}
+	}
+}
+
+// TestSolutionPool_RejectsOutsideGap covers the bug Gap used to be written
+// but never read for: without this check, Add only ever compares a
+// candidate to the pool's current worst member, so an empty or
+// not-yet-full pool would happily admit a solution arbitrarily far from
+// the incumbent.
+func TestSolutionPool_RejectsOutsideGap(t *testing.T) {
+	p := NewSolutionPool(PoolOptions{Size: 5, Gap: 0.1})
+
+	if !p.Add(solution{z: 10}) {
+		t.Fatalf("expected the first candidate (the incumbent) to be kept")
+	}
+	if p.Add(solution{z: 20}) {
+		t.Fatalf("expected a candidate outside the gap of the incumbent to be rejected")
+	}
+	if !p.Add(solution{z: 10.5}) {
+		t.Fatalf("expected a candidate within the gap of the incumbent to be kept")
+	}
+	if p.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", p.Len())
+	}
+}
+
+func TestSolutionPool_NearExisting(t *testing.T) {
+	p := NewSolutionPool(PoolOptions{Size: 5, Gap: 0.1})
+	p.Add(solution{z: 10})
+
+	if !p.NearExisting(10.05) {
+		t.Fatalf("expected 10.05 to be within gap of the pooled 10")
+	}
+	if p.NearExisting(50) {
+		t.Fatalf("expected 50 to be outside gap of the pooled 10")
+	}
+}
+
+func TestNoGoodCut(t *testing.T) {
+	x := []float64{1, 0, 1}
+	binary := []bool{true, true, true}
+
+	coeffs, rhs := noGoodCut(x, binary)
+	want := []float64{1, -1, 1}
+	for i, c := range coeffs {
+		if c != want[i] {
+			t.Fatalf("coeffs[%d] = %v, want %v", i, c, want[i])
+		}
+	}
+	if rhs != 1 {
+		t.Fatalf("rhs = %v, want 1", rhs)
+	}
+
+	// x itself must violate the cut it produced.
+	var lhs float64
+	for i, c := range coeffs {
+		lhs += c * x[i]
+	}
+	if lhs <= rhs {
+		t.Fatalf("cut does not exclude the point it was derived from: %v <= %v", lhs, rhs)
+	}
+}
+
+func TestNoGoodCut_IgnoresNonBinary(t *testing.T) {
+	x := []float64{1, 2.5}
+	binary := []bool{true, false}
+
+	coeffs, _ := noGoodCut(x, binary)
+	if coeffs[1] != 0 {
+		t.Fatalf("coeffs[1] = %v, want 0 for a non-binary variable", coeffs[1])
+	}
+}
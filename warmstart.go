@@ -0,0 +1,238 @@
+package ilp
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// basis is the optimal basic index set of a solved standard-form LP
+// relaxation: the column of A basic in each row, in row order. Caching it
+// on a subProblem lets a child built by tightening one bound or adding one
+// bnb row (the overwhelming majority of nodes) start its resolve from the
+// parent's optimal vertex via lp.Simplex's initialBasic parameter, instead
+// of from simplex's default all-slack starting basis.
+type basis []int
+
+// dualRepairMaxIters bounds how many dual simplex pivots are attempted to
+// restore primal feasibility to a warm-started basis before giving up and
+// falling back to cold-starting the child from scratch.
+const dualRepairMaxIters = 50
+
+// repairTol is the tolerance below which a basic value is still considered
+// feasible (>= 0), and below which a reduced cost is still considered
+// dual-feasible (>= 0).
+const repairTol = 1e-9
+
+// BoundBranchHint carries just enough information about how a child
+// subProblem's bounds differ from its parent for solveRelaxationWarm to
+// extend the parent's basis across Convert's one new row instead of giving
+// up on warm-starting entirely (see ExtendBasisForBound). Nil means
+// "unknown, or more than one bound changed", which falls back to requiring
+// an exact dimension match between parentBasis and A.
+type BoundBranchHint struct {
+	// Pos is the row index Convert will assign the newly tightened bound
+	// (see bounds.go's boundRowPosition).
+	Pos int
+
+	// SlackCol is the column of that new row's own slack variable in the
+	// child's standard-form A.
+	SlackCol int
+}
+
+// solveRelaxationWarm solves the standard-form LP (minimize c^T x s.t.
+// A*x = b, x >= 0) for a branch-and-bound node, reusing parentBasis as the
+// starting basis when it is still (or can cheaply be repaired into) a
+// primal-feasible vertex, and falling back to letting lp.Simplex pick its
+// own starting basis otherwise. Tightening a single bound during branching
+// typically leaves the parent's basis dual-feasible but primal-infeasible
+// in exactly the branched row, which is the case dualRepair targets.
+//
+// Folding a newly-tightened bound into Convert's output grows A by one row
+// relative to the parent (see bounds.go's Convert), which used to make
+// dualRepair bail out on a dimension mismatch for exactly the node shape
+// warm-starting is meant to help: a child that differs from its parent by
+// one bound. hint, when the caller can supply it (a single bound just went
+// from unset to finite), lets ExtendBasisForBound grow parentBasis to match
+// before repair is attempted instead of discarding it outright.
+func solveRelaxationWarm(c []float64, A mat.Matrix, b []float64, parentBasis basis, hint *BoundBranchHint) (float64, []float64, basis, error) {
+	var start []int
+	if parentBasis != nil {
+		candidate := []int(parentBasis)
+		if hint != nil {
+			if m, _ := A.Dims(); len(candidate) == m-1 {
+				candidate = ExtendBasisForBound(parentBasis, hint.Pos, hint.SlackCol)
+			}
+		}
+		if repaired, ok := dualRepair(A, b, c, candidate, dualRepairMaxIters); ok {
+			start = repaired
+		}
+	}
+
+	f, x, err := lp.Simplex(c, A, b, 0, start)
+	if err != nil {
+		return f, x, nil, err
+	}
+	return f, x, basisFromSolution(A, x), nil
+}
+
+// ExtendBasisForBound adapts parentBasis - sized for the parent's Converted
+// A - to a child whose Converted A gained exactly one new bound row at
+// index pos (from bounds.go's boundRowPosition). Rows before pos keep
+// their parent basic variable unchanged; rows at or after pos shift down
+// by one to make room for the new row, which starts basic in its own
+// slack column slackCol. Seeding a brand-new row with its own slack basic
+// is trivially dual-feasible: that slack has a zero objective coefficient
+// and, being the only row it appears in, can't make any other column's
+// reduced cost negative, so the rest of the parent's dual-feasible basis
+// is preserved exactly. The result is handed to dualRepair, which then
+// only needs to restore primal feasibility, the same as for an ordinary
+// single-bound branch.
+func ExtendBasisForBound(parentBasis basis, pos, slackCol int) basis {
+	extended := make(basis, 0, len(parentBasis)+1)
+	extended = append(extended, parentBasis[:pos]...)
+	extended = append(extended, slackCol)
+	extended = append(extended, parentBasis[pos:]...)
+	return extended
+}
+
+// dualRepair runs dual simplex pivots against candidate until it is also
+// primal-feasible for A*x = b, x >= 0, or maxIters is exhausted. candidate
+// is assumed dual-feasible going in (true of a parent's optimal basis,
+// since tightening a bound never changes c); each pivot here preserves
+// that invariant by picking the entering column with the smallest reduced
+// cost per unit of infeasibility repaired, same as the textbook dual
+// simplex ratio test. Returns the repaired basis and true on success, or
+// nil, false if a row has no valid dual pivot (candidate wasn't
+// repairable - the node is primal infeasible) or a linear solve fails.
+func dualRepair(A mat.Matrix, b, c []float64, candidate []int, maxIters int) ([]int, bool) {
+	m, n := A.Dims()
+	if len(candidate) != m {
+		return nil, false
+	}
+	cur := append([]int{}, candidate...)
+
+	for iter := 0; iter < maxIters; iter++ {
+		ab := basisMatrix(A, cur)
+
+		var xb mat.VecDense
+		if err := xb.SolveVec(ab, mat.NewVecDense(m, b)); err != nil {
+			return nil, false
+		}
+
+		row, worst := -1, -repairTol
+		for i := 0; i < m; i++ {
+			if v := xb.AtVec(i); v < worst {
+				row, worst = i, v
+			}
+		}
+		if row == -1 {
+			return cur, true // every basic value is feasible
+		}
+
+		// y prices every column at the current basis: cbar_j = c_j - y.A_j.
+		cb := make([]float64, m)
+		for i, j := range cur {
+			cb[i] = c[j]
+		}
+		var y mat.VecDense
+		if err := y.SolveVec(ab.T(), mat.NewVecDense(m, cb)); err != nil {
+			return nil, false
+		}
+
+		// alphaRow is row `row` of Ab^-1 * A: solve Ab^T w = e_row, then
+		// alphaRow_j = w . A_j for every column j.
+		e := make([]float64, m)
+		e[row] = 1
+		var w mat.VecDense
+		if err := w.SolveVec(ab.T(), mat.NewVecDense(m, e)); err != nil {
+			return nil, false
+		}
+
+		basicSet := make(map[int]bool, m)
+		for _, j := range cur {
+			basicSet[j] = true
+		}
+
+		enter, bestRatio := -1, math.Inf(1)
+		for j := 0; j < n; j++ {
+			if basicSet[j] {
+				continue
+			}
+			col := colOf(A, j, m)
+			alpha := mat.Dot(&w, col)
+			if alpha >= -repairTol {
+				continue // only columns that can raise x[row] are valid dual pivots
+			}
+			cbar := c[j] - mat.Dot(&y, col)
+			ratio := cbar / -alpha
+			if ratio < bestRatio {
+				enter, bestRatio = j, ratio
+			}
+		}
+		if enter == -1 {
+			return nil, false // dual unbounded: no valid pivot, the node itself is primal infeasible
+		}
+
+		cur[row] = enter
+	}
+
+	return nil, false
+}
+
+// basisMatrix assembles the m x m matrix of A's columns named by idx.
+func basisMatrix(A mat.Matrix, idx []int) *mat.Dense {
+	m, _ := A.Dims()
+	ab := mat.NewDense(m, len(idx), nil)
+	for k, j := range idx {
+		for i := 0; i < m; i++ {
+			ab.Set(i, k, A.At(i, j))
+		}
+	}
+	return ab
+}
+
+// colOf returns column j of A as a length-m vector.
+func colOf(A mat.Matrix, j, m int) *mat.VecDense {
+	col := make([]float64, m)
+	for i := 0; i < m; i++ {
+		col[i] = A.At(i, j)
+	}
+	return mat.NewVecDense(m, col)
+}
+
+// basisFromSolution reconstructs a best-effort basis from a solved x: the
+// indices of its largest |x| entries, one per row of A. lp.Simplex doesn't
+// expose the basis it actually pivoted to, so this is an approximation
+// used only to seed the *next* warm start; a wrong guess just means that
+// child's dualRepair fails and it falls back to a cold solve, not an
+// incorrect answer.
+func basisFromSolution(A mat.Matrix, x []float64) basis {
+	m, _ := A.Dims()
+	type idxVal struct {
+		idx int
+		val float64
+	}
+	vals := make([]idxVal, len(x))
+	for i, v := range x {
+		vals[i] = idxVal{i, math.Abs(v)}
+	}
+	// partial selection sort for the top m entries; m is a single node's
+	// row count, never large enough to warrant sort.Slice's overhead.
+	for i := 0; i < m && i < len(vals); i++ {
+		best := i
+		for k := i + 1; k < len(vals); k++ {
+			if vals[k].val > vals[best].val {
+				best = k
+			}
+		}
+		vals[i], vals[best] = vals[best], vals[i]
+	}
+
+	b := make(basis, 0, m)
+	for i := 0; i < m && i < len(vals); i++ {
+		b = append(b, vals[i].idx)
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+package ilp
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestExtendBasisForBound_InsertsAndShifts(t *testing.T) {
+	parent := basis{10, 11, 12}
+
+	got := ExtendBasisForBound(parent, 1, 99)
+	want := basis{10, 99, 11, 12}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestExtendBasisForBound_AppendAtEnd(t *testing.T) {
+	parent := basis{10, 11, 12}
+	got := ExtendBasisForBound(parent, 3, 99)
+	want := basis{10, 11, 12, 99}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// TestSolveRelaxationWarm_ExtendedBasisMatchesCold checks that warm-starting
+// through a one-row bound insertion (the case dualRepair used to bail out
+// of outright) reaches the same optimum a cold solve does.
+//
+// Parent: minimize -2*x1 - x2 s.t. x1 + x2 <= 10 (slack s1), solved to the
+// optimal vertex x1=10, x2=0, s1=0, basis {0} (x1 basic in the only row).
+func TestSolveRelaxationWarm_ExtendedBasisMatchesCold(t *testing.T) {
+	// Columns: x1, x2, s1 (slack for x1+x2<=10).
+	// Parent A: [1 1 1], b: [10]; its optimal basis is {0}.
+	parentBasis := basis{0} // x1 basic at 10, x2 and s1 nonbasic at 0
+
+	// Child adds a new bound row x1 <= 7 (inserted at row 1, the end here),
+	// with its own slack s2 as column 3. New A: columns x1,x2,s1,s2. The
+	// extended basis {x1, s2} puts s2 at -3, which dualRepair must fix.
+	childA := mat.NewDense(2, 4, []float64{
+		1, 1, 1, 0,
+		1, 0, 0, 1,
+	})
+	childB := []float64{10, 7}
+	childC := []float64{-2, -1, 0, 0}
+
+	hint := &BoundBranchHint{Pos: 1, SlackCol: 3}
+	_, xWarm, _, err := solveRelaxationWarm(childC, childA, childB, parentBasis, hint)
+	if err != nil {
+		t.Fatalf("warm solve failed: %v", err)
+	}
+
+	_, xCold, _, err := solveRelaxationWarm(childC, childA, childB, nil, nil)
+	if err != nil {
+		t.Fatalf("cold solve failed: %v", err)
+	}
+
+	// x1 is newly capped at 7, so the optimum shifts to x1=7, x2=3 (still
+	// x1+x2=10, the binding row-1 constraint) rather than the parent's
+	// x1=10, x2=0.
+	if d := xWarm[0] + xWarm[1]; d < 9.999 || d > 10.001 {
+		t.Fatalf("expected x1+x2=10 at the optimum, got %v (x=%v)", d, xWarm)
+	}
+	if math.Abs(xCold[0]-xWarm[0]) > 1e-6 || math.Abs(xCold[1]-xWarm[1]) > 1e-6 {
+		t.Fatalf("warm-started solve %v disagrees with cold solve %v", xWarm, xCold)
+	}
+}